@@ -0,0 +1,8 @@
+drop table if exists publishers;
+
+create table publishers (
+  `id` INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `name` VARCHAR(255) NOT NULL,
+  `key` VARCHAR(255) NOT NULL UNIQUE,
+  `founded` INT NOT NULL
+);
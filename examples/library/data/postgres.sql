@@ -4,7 +4,8 @@ create table genres (
   id SERIAL,
   name TEXT,
   parent_id INTEGER,
-  genre_references jsonb
+  genre_references jsonb,
+  tags TEXT[]
 );
 
 drop table if exists multi_auto;
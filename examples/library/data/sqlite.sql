@@ -4,9 +4,12 @@ create table authors (
   system_id INTEGER PRIMARY KEY,
   name TEXT,
   university_id INTEGER,
+  nickname TEXT,
   rating REAL NOT NULL DEFAULT '100.00',
   flags INTEGER NOT NULL DEFAULT 0,
-  birthday Date NOT NULL
+  birthday Date NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
 drop table if exists books;
@@ -16,5 +19,20 @@ create table books (
   title TEXT,
   author INTEGER NOT NULL,
   series INTEGER,
-  year_published INTEGER NOT NULL
+  year_published INTEGER NOT NULL,
+  subtitle TEXT,
+  page_count INTEGER,
+  isbn TEXT
+);
+
+create unique index books_title on books (title);
+
+drop table if exists members;
+
+create table members (
+  id INTEGER PRIMARY KEY,
+  name TEXT NOT NULL UNIQUE,
+  status TEXT NOT NULL DEFAULT 'active',
+  version INTEGER NOT NULL DEFAULT 0,
+  deleted_at DATETIME
 );
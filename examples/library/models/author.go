@@ -18,13 +18,16 @@ const (
 
 // Author represents an author of a book.
 type Author struct {
-	table        bool          `marlow:"tableName=authors"`
-	ID           int           `marlow:"column=system_id&autoIncrement=true"`
-	Name         string        `marlow:"column=name"`
-	UniversityID sql.NullInt64 `marlow:"column=university_id"`
-	ReaderRating float64       `marlow:"column=rating"`
-	AuthorFlags  uint8         `marlow:"column=flags&bitmask"`
-	Birthday     time.Time     `marlow:"column=birthday"`
+	table        bool           `marlow:"tableName=authors&sharedStores=true"`
+	ID           int            `marlow:"column=system_id&autoIncrement=true&primaryKey=true"`
+	Name         string         `marlow:"column=name"`
+	UniversityID sql.NullInt64  `marlow:"column=university_id"`
+	Nickname     sql.NullString `marlow:"column=nickname"`
+	ReaderRating float64        `marlow:"column=rating"`
+	AuthorFlags  uint8          `marlow:"column=flags&bitmask"`
+	Birthday     time.Time      `marlow:"column=birthday"`
+	CreatedAt    time.Time      `marlow:"column=created_at&createdAt"`
+	UpdatedAt    time.Time      `marlow:"column=updated_at&updatedAt"`
 }
 
 func (a *Author) String() string {
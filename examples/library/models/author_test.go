@@ -77,6 +77,21 @@ func Test_Author(t *testing.T) {
 			g.Assert(r).Equal("WHERE authors.university_id IS NULL")
 		})
 
+		g.It("supports a combination of null & valid values on sql.NullInt64 fields", func() {
+			r := fmt.Sprintf("%s", &AuthorBlueprint{
+				UniversityID: []sql.NullInt64{
+					{Valid: false},
+					{Int64: 10, Valid: true},
+				},
+			})
+			g.Assert(r).Equal("WHERE (authors.university_id IN (?) OR authors.university_id IS NULL)")
+		})
+
+		g.It("supports NOT NULL selection using the NotNull field on sql.NullString fields", func() {
+			r := fmt.Sprintf("%s", &AuthorBlueprint{NicknameNotNull: true})
+			g.Assert(r).Equal("WHERE authors.nickname NOT NULL")
+		})
+
 		g.It("supports range on ID column querying", func() {
 			r := fmt.Sprintf("%s", &AuthorBlueprint{
 				IDRange: []int{1, 2},
@@ -128,6 +143,50 @@ func Test_Author(t *testing.T) {
 
 			g.Assert(r).Equal("WHERE (authors.system_id > ? AND authors.system_id < ?) OR authors.name LIKE ?")
 		})
+
+		g.It("supports open ended & inclusive bounds on ID column querying", func() {
+			min, max := 1, 4
+			r := fmt.Sprintf("%s", &AuthorBlueprint{IDGte: &min, IDLte: &max})
+			g.Assert(r).Equal("WHERE (authors.system_id >= ? AND authors.system_id <= ?)")
+
+			r = fmt.Sprintf("%s", &AuthorBlueprint{IDGt: &min})
+			g.Assert(r).Equal("WHERE (authors.system_id > ?)")
+		})
+
+		g.It("keeps the bounds of a column together when the blueprint is inclusive", func() {
+			min, max := 1, 4
+			r := fmt.Sprintf("%s", &AuthorBlueprint{
+				NameLike:  []string{"%rodger%"},
+				IDGt:      &min,
+				IDLt:      &max,
+				Inclusive: true,
+			})
+
+			g.Assert(r).Equal("WHERE (authors.system_id > ? AND authors.system_id < ?) OR authors.name LIKE ?")
+		})
+
+		g.It("supports case insensitive searches on string columns", func() {
+			r := fmt.Sprintf("%s", &AuthorBlueprint{NameILike: []string{"%rodger%"}})
+			g.Assert(r).Equal("WHERE LOWER(authors.name) LIKE LOWER(?)")
+
+			r = fmt.Sprintf("%s", &AuthorBlueprint{NamePrefix: []string{"rod"}, NameSuffix: []string{"ger"}})
+			expected := "WHERE LOWER(authors.name) LIKE LOWER(?) ESCAPE '!' AND LOWER(authors.name) LIKE LOWER(?) ESCAPE '!'"
+			g.Assert(r).Equal(expected)
+		})
+
+		g.It("escapes the wildcards of the prefix, contains & suffix searches", func() {
+			bp := &AuthorBlueprint{
+				NamePrefix:   []string{"100%"},
+				NameContains: []string{"snake_case"},
+				NameSuffix:   []string{"!"},
+			}
+			g.Assert(bp.Values()).Equal([]interface{}{"100!%%", "%snake!_case%", "%!!"})
+		})
+
+		g.It("supports 'NOT IN' on ID column querying", func() {
+			r := fmt.Sprintf("%s", &AuthorBlueprint{IDNot: []int{1, 2}, ID: []int{3}})
+			g.Assert(r).Equal("WHERE authors.system_id IN (?) AND authors.system_id NOT IN (?,?)")
+		})
 	})
 
 	g.Describe("Author model & generated store test suite", func() {
@@ -219,6 +278,64 @@ func Test_Author(t *testing.T) {
 			g.Assert(len(authors)).Equal(2)
 		})
 
+		g.It("allows the consumer to search for authors by inclusive ID bounds", func() {
+			min, max := 1, 4
+			authors, e := store.FindAuthors(&AuthorBlueprint{IDGte: &min, IDLte: &max})
+			g.Assert(e).Equal(nil)
+			g.Assert(len(authors)).Equal(4)
+		})
+
+		g.It("allows the consumer to search for authors by open ended ID bounds", func() {
+			min := 1337
+			authors, e := store.FindAuthors(&AuthorBlueprint{IDGt: &min})
+			g.Assert(e).Equal(nil)
+			g.Assert(len(authors)).Equal(1)
+			g.Assert(authors[0].ID).Equal(1338)
+		})
+
+		g.It("allows the consumer to exclude authors by ID", func() {
+			max := 4
+			authors, e := store.FindAuthors(&AuthorBlueprint{IDLte: &max, IDNot: []int{2, 3}})
+			g.Assert(e).Equal(nil)
+			g.Assert(len(authors)).Equal(2)
+			g.Assert(authors[0].ID).Equal(1)
+			g.Assert(authors[1].ID).Equal(4)
+		})
+
+		g.It("allows the consumer to search for authors by name ignoring case", func() {
+			count, e := store.CountAuthors(&AuthorBlueprint{NameSuffix: []string{"ED AUTHOR"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(1)
+
+			count, e = store.CountAuthors(&AuthorBlueprint{NameILike: []string{"LEARNED%"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(1)
+
+			count, e = store.CountAuthors(&AuthorBlueprint{NamePrefix: []string{"Author-10"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(11)
+		})
+
+		g.It("allows the consumer to search for authors by names containing wildcards", func() {
+			id, e := store.CreateAuthors(Author{Name: "50% off_sale"})
+			g.Assert(e).Equal(nil)
+
+			count, e := store.CountAuthors(&AuthorBlueprint{NameContains: []string{"0% OFF_"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(1)
+
+			count, e = store.CountAuthors(&AuthorBlueprint{NameContains: []string{"_"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(1)
+
+			count, e = store.CountAuthors(&AuthorBlueprint{NameContains: []string{"%"}, NameSuffix: []string{"sale"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(1)
+
+			_, e = store.DeleteAuthors(&AuthorBlueprint{ID: []int{int(id)}})
+			g.Assert(e).Equal(nil)
+		})
+
 		g.It("correctly serializes null/not null values into a sql.NullInt64 field", func() {
 			authors, e := store.FindAuthors(&AuthorBlueprint{
 				ID: []int{1337, 1338},
@@ -547,6 +664,61 @@ func Test_Author(t *testing.T) {
 
 		})
 
+		g.Describe("sql.NullString field interactions", func() {
+			var blueprint *AuthorBlueprint
+
+			g.BeforeEach(func() {
+				_, e := store.CreateAuthors([]Author{
+					{Name: "nickname author 001", Nickname: sql.NullString{String: "The Bard", Valid: true}},
+					{Name: "nickname author 002"},
+				}...)
+				g.Assert(e).Equal(nil)
+				blueprint = &AuthorBlueprint{NameLike: []string{"nickname author%"}}
+			})
+
+			g.AfterEach(func() {
+				_, e := store.DeleteAuthors(blueprint)
+				g.Assert(e).Equal(nil)
+			})
+
+			g.It("allows users to search by null & valid values", func() {
+				count, e := store.CountAuthors(&AuthorBlueprint{
+					NameLike: blueprint.NameLike,
+					Nickname: []sql.NullString{{Valid: false}},
+				})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(1)
+
+				count, e = store.CountAuthors(&AuthorBlueprint{
+					NameLike:        blueprint.NameLike,
+					NicknameNotNull: true,
+				})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(1)
+			})
+
+			g.It("allows users to search by like", func() {
+				names, e := store.SelectAuthorNames(&AuthorBlueprint{NicknameLike: []string{"%Bard"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(names).Equal([]string{"nickname author 001"})
+			})
+
+			g.It("allows users to update the field using nil", func() {
+				_, e := store.UpdateAuthorNickname(nil, blueprint)
+				g.Assert(e).Equal(nil)
+				nicknames, e := store.SelectAuthorNicknames(blueprint)
+				g.Assert(e).Equal(nil)
+				g.Assert(len(nicknames)).Equal(2)
+				g.Assert(nicknames[0].Valid || nicknames[1].Valid).Equal(false)
+			})
+		})
+
+		g.It("allows consumer to search by a range of university ids", func() {
+			count, e := store.CountAuthors(&AuthorBlueprint{UniversityIDRange: []int64{9, 11}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count > 0).Equal(true)
+		})
+
 		g.Describe("bitwise operations on bitmask", func() {
 			var blueprint *AuthorBlueprint
 
@@ -595,6 +767,77 @@ func Test_Author(t *testing.T) {
 			})
 		})
 
+		g.Describe("timestamp columns", func() {
+			var created, updated time.Time
+			var clockStore AuthorStore
+			var blueprint *AuthorBlueprint
+
+			g.BeforeEach(func() {
+				created = time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+				updated = created.Add(time.Hour)
+				clockStore = store.WithClock(func() time.Time { return created })
+
+				id, e := clockStore.CreateAuthors(Author{Name: "Timestamp Author", Birthday: created})
+				g.Assert(e).Equal(nil)
+				blueprint = &AuthorBlueprint{ID: []int{int(id)}}
+				clockStore = store.WithClock(func() time.Time { return updated })
+			})
+
+			g.AfterEach(func() {
+				_, e := store.DeleteAuthors(blueprint)
+				g.Assert(e).Equal(nil)
+			})
+
+			g.It("fills the created_at & updated_at columns on insert", func() {
+				authors, e := store.FindAuthors(blueprint)
+				g.Assert(e).Equal(nil)
+				g.Assert(len(authors)).Equal(1)
+				g.Assert(authors[0].CreatedAt.Equal(created)).Equal(true)
+				g.Assert(authors[0].UpdatedAt.Equal(created)).Equal(true)
+			})
+
+			g.It("does not overwrite explicitly provided timestamps on insert", func() {
+				explicit := &Author{Name: "Explicit Author", Birthday: created, CreatedAt: updated}
+				ids, e := clockStore.CreateAuthorsReturning(explicit)
+				g.Assert(e).Equal(nil)
+				g.Assert(explicit.CreatedAt.Equal(updated)).Equal(true)
+				g.Assert(explicit.UpdatedAt.Equal(updated)).Equal(true)
+				_, e = store.DeleteAuthors(&AuthorBlueprint{ID: ids})
+				g.Assert(e).Equal(nil)
+			})
+
+			g.It("sets the updated_at column when updating a single field", func() {
+				_, e := clockStore.UpdateAuthorName("Renamed Author", blueprint)
+				g.Assert(e).Equal(nil)
+				stamps, e := store.SelectAuthorUpdatedAts(blueprint)
+				g.Assert(e).Equal(nil)
+				g.Assert(stamps[0].Equal(updated)).Equal(true)
+			})
+
+			g.It("sets the updated_at column when updating a bitmask", func() {
+				_, e := clockStore.AddAuthorAuthorFlags(AuthorImported, blueprint)
+				g.Assert(e).Equal(nil)
+				stamps, e := store.SelectAuthorUpdatedAts(blueprint)
+				g.Assert(e).Equal(nil)
+				g.Assert(stamps[0].Equal(updated)).Equal(true)
+			})
+
+			g.It("sets the updated_at column, leaving created_at untouched, when updating the whole record", func() {
+				authors, e := store.FindAuthors(blueprint)
+				g.Assert(e).Equal(nil)
+				author := authors[0]
+				author.CreatedAt = time.Time{}
+				_, e = clockStore.UpdateAuthor(author)
+				g.Assert(e).Equal(nil)
+				g.Assert(author.UpdatedAt.Equal(updated)).Equal(true)
+
+				authors, e = store.FindAuthors(blueprint)
+				g.Assert(e).Equal(nil)
+				g.Assert(authors[0].CreatedAt.Equal(created)).Equal(true)
+				g.Assert(authors[0].UpdatedAt.Equal(updated)).Equal(true)
+			})
+		})
+
 		g.Describe("DeleteAuthors", func() {
 
 			g.It("returns an error and a negative number with an empty blueprint", func() {
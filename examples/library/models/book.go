@@ -9,12 +9,15 @@ import "database/sql"
 
 // Book represents a book in the example application
 type Book struct {
-	table         string        `marlow:"defaultLimit=10"`
-	ID            int           `marlow:"column=system_id&autoIncrement=true"`
+	table         string        `marlow:"defaultLimit=10&upsertable=true&sharedStores=true"`
+	ID            int           `marlow:"column=system_id&autoIncrement=true&primaryKey=true"`
 	Title         string        `marlow:"column=title"`
-	AuthorID      int           `marlow:"column=author"`
+	AuthorID      int           `marlow:"column=author&references=Author.ID"`
 	SeriesID      sql.NullInt64 `marlow:"column=series"`
 	YearPublished int           `marlow:"column=year_published" json:"year_published"`
+	Subtitle      *string       `marlow:"column=subtitle"`
+	PageCount     *int          `marlow:"column=page_count"`
+	ISBN          ISBN          `marlow:"column=isbn&kind=string"`
 }
 
 // String returns the book with good info.
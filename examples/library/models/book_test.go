@@ -4,6 +4,7 @@ import "os"
 import "io"
 import "fmt"
 import "bytes"
+import "context"
 import "strings"
 import "testing"
 import _ "github.com/mattn/go-sqlite3"
@@ -59,6 +60,44 @@ func Test_Book(t *testing.T) {
 			expected := "WHERE (books.system_id > ? AND books.system_id < ?) OR books.title LIKE ? OR books.title LIKE ?"
 			g.Assert(str).Equal(expected)
 		})
+
+		g.It("matches null columns for the nil values of pointer fields", func() {
+			subtitle := "There and Back Again"
+			str := fmt.Sprintf("%s", &BookBlueprint{Subtitle: []*string{nil, &subtitle}})
+			g.Assert(str).Equal("WHERE (books.subtitle IN (?) OR books.subtitle IS NULL)")
+
+			str = fmt.Sprintf("%s", &BookBlueprint{Subtitle: []*string{nil}})
+			g.Assert(str).Equal("WHERE books.subtitle IS NULL")
+		})
+
+		g.It("renders the groups of nested blueprints as parenthesized clauses", func() {
+			str := fmt.Sprintf("%s", &BookBlueprint{
+				YearPublished: []int{2001},
+				Or: []*BookBlueprint{
+					{Title: []string{"book-1"}},
+					{TitleLike: []string{"book-%"}, IDRange: []int{1, 4}},
+				},
+			})
+			expected := "WHERE books.year_published IN (?) AND " +
+				"((books.title IN (?)) OR ((books.system_id > ? AND books.system_id < ?) AND books.title LIKE ?))"
+			g.Assert(str).Equal(expected)
+		})
+
+		g.It("allows and groups in inclusive blueprints", func() {
+			bp := &BookBlueprint{
+				Title:     []string{"book-1"},
+				And:       []*BookBlueprint{{YearPublished: []int{2002}}, nil, {}, {AuthorID: []int{21}}},
+				Inclusive: true,
+			}
+			str := fmt.Sprintf("%s", bp)
+			g.Assert(str).Equal("WHERE books.title IN (?) OR ((books.year_published IN (?)) AND (books.author IN (?)))")
+			g.Assert(bp.Values()).Equal([]interface{}{"book-1", 2002, 21})
+		})
+
+		g.It("does not add a clause for empty groups", func() {
+			str := fmt.Sprintf("%s", &BookBlueprint{Or: []*BookBlueprint{{}}, And: []*BookBlueprint{}})
+			g.Assert(str).Equal("")
+		})
 	})
 
 	g.Describe("Book model & generated store", func() {
@@ -191,6 +230,29 @@ func Test_Book(t *testing.T) {
 			g.Assert(len(books)).Equal(2)
 		})
 
+		g.It("allows the consumer to order books by a known column", func() {
+			books, e := store.FindBooks(&BookBlueprint{OrderBy: "system_id", OrderDirection: "desc"})
+			g.Assert(e).Equal(nil)
+			g.Assert(len(books)).Equal(10)
+			g.Assert(books[0].ID > books[1].ID).Equal(true)
+		})
+
+		g.It("allows the consumer to order selected fields by a known column", func() {
+			ids, e := store.SelectBookIDs(&BookBlueprint{ID: []int{1, 2, 3}, OrderBy: "system_id", OrderDirection: "DESC"})
+			g.Assert(e).Equal(nil)
+			g.Assert(ids).Equal([]int{3, 2, 1})
+		})
+
+		g.It("returns an error when ordering by an unknown column", func() {
+			_, e := store.FindBooks(&BookBlueprint{OrderBy: "title; drop table books"})
+			g.Assert(e == nil).Equal(false)
+		})
+
+		g.It("returns an error when ordering in an unknown direction", func() {
+			_, e := store.SelectBookTitles(&BookBlueprint{OrderBy: "title", OrderDirection: "sideways"})
+			g.Assert(e == nil).Equal(false)
+		})
+
 		g.It("allows the consumer to search for books w/ multiple fields", func() {
 			books, e := store.FindBooks(&BookBlueprint{
 				ID:                 []int{1},
@@ -201,6 +263,19 @@ func Test_Book(t *testing.T) {
 			g.Assert(len(books)).Equal(1)
 		})
 
+		g.It("allows the consumer to search for books w/ groups of nested blueprints", func() {
+			books, e := store.FindBooks(&BookBlueprint{
+				Or:      []*BookBlueprint{{YearPublished: []int{2001}}, {Title: []string{"book-3"}}},
+				IDRange: []int{0, 4},
+				OrderBy: "system_id",
+			})
+
+			g.Assert(e).Equal(nil)
+			g.Assert(len(books)).Equal(2)
+			g.Assert(books[0].ID).Equal(1)
+			g.Assert(books[1].ID).Equal(3)
+		})
+
 		g.Describe("store.CountBooks", func() {
 
 			g.It("allows the consumer to count books with nil blueprint", func() {
@@ -355,6 +430,376 @@ func Test_Book(t *testing.T) {
 			})
 		})
 
+		g.Describe("author relations", func() {
+			var books []*Book
+
+			g.Before(func() {
+				e := addAuthorRow(
+					db,
+					[]string{"21", "'first author'", "'1901-01-01'"},
+					[]string{"31", "'second author'", "'1902-02-02'"},
+				)
+				g.Assert(e).Equal(nil)
+			})
+
+			g.BeforeEach(func() {
+				var e error
+				books, e = store.FindBooks(&BookBlueprint{ID: []int{2, 3, 4}, OrderBy: "system_id"})
+				g.Assert(e).Equal(nil)
+				g.Assert(len(books)).Equal(3)
+			})
+
+			g.It("loads the authors of the books using a single query", func() {
+				authors, e := store.FindBookAuthors(append(books, nil, books[0]))
+				g.Assert(e).Equal(nil)
+				g.Assert(len(authors)).Equal(2)
+				g.Assert(authors[21].Name).Equal("first author")
+				g.Assert(authors[31].Name).Equal("second author")
+				g.Assert(strings.Count(queryLog.(*bytes.Buffer).String(), "FROM authors")).Equal(1)
+			})
+
+			g.It("returns an empty map without querying if there are no books", func() {
+				authors, e := store.FindBookAuthors(nil)
+				g.Assert(e).Equal(nil)
+				g.Assert(len(authors)).Equal(0)
+				g.Assert(strings.Contains(queryLog.(*bytes.Buffer).String(), "FROM authors")).Equal(false)
+			})
+
+			g.It("loads the authors within the transaction of the store", func() {
+				var authors map[int]*Author
+
+				e := NewStores(db, queryLog).Transaction(func(stores Stores) error {
+					var e error
+					authors, e = stores.Books.FindBookAuthors(books)
+					return e
+				})
+
+				g.Assert(e).Equal(nil)
+				g.Assert(len(authors)).Equal(2)
+			})
+
+			g.It("loads the books of each author using a single query", func() {
+				authors := []*Author{{ID: 21}, {ID: 31}, {ID: 21}, {ID: -1}}
+				queryLog.(*bytes.Buffer).Reset()
+				related, e := store.FindAuthorBooks(authors)
+				g.Assert(e).Equal(nil)
+				g.Assert(len(related)).Equal(2)
+				g.Assert(len(related[21])).Equal(1)
+				g.Assert(related[21][0].Title).Equal("book-2")
+				g.Assert(related[31][0].Title).Equal("book-3")
+				g.Assert(strings.Count(queryLog.(*bytes.Buffer).String(), "FROM books")).Equal(1)
+			})
+
+			g.It("allows the consumer to find books by the columns of their author", func() {
+				found, e := store.FindBooks(&BookBlueprint{Author: &AuthorBlueprint{NameLike: []string{"first%"}}})
+				g.Assert(e).Equal(nil)
+				g.Assert(len(found)).Equal(1)
+				g.Assert(found[0].Title).Equal("book-2")
+				join := "FROM books JOIN authors ON authors.system_id = books.author WHERE"
+				g.Assert(strings.Contains(queryLog.(*bytes.Buffer).String(), join)).Equal(true)
+			})
+
+			g.It("merges the author clauses with the clauses of the book blueprint", func() {
+				blueprint := &BookBlueprint{
+					ID:     []int{2, 3, 4},
+					Author: &AuthorBlueprint{Name: []string{"second author", "first author"}},
+				}
+
+				count, e := store.CountBooks(blueprint)
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(2)
+
+				blueprint.OrderBy = "title"
+				titles, e := store.SelectBookTitles(blueprint)
+				g.Assert(e).Equal(nil)
+				g.Assert(titles).Equal([]string{"book-2", "book-3"})
+			})
+
+			g.It("joins the authors table for author blueprints nested in the members of groups", func() {
+				queryLog.(*bytes.Buffer).Reset()
+				found, e := store.FindBooks(&BookBlueprint{
+					Or: []*BookBlueprint{
+						{Author: &AuthorBlueprint{Name: []string{"second author"}}},
+						{Title: []string{"book-2"}},
+					},
+					OrderBy: "title",
+				})
+				g.Assert(e).Equal(nil)
+				g.Assert(len(found)).Equal(2)
+				g.Assert(found[0].Title).Equal("book-2")
+				g.Assert(found[1].Title).Equal("book-3")
+				g.Assert(strings.Count(queryLog.(*bytes.Buffer).String(), "JOIN authors")).Equal(1)
+			})
+
+			g.It("joins the authors table once for author blueprints of the book & the members of its groups", func() {
+				count, e := store.CountBooks(&BookBlueprint{
+					Author: &AuthorBlueprint{NameLike: []string{"%author"}},
+					And:    []*BookBlueprint{{Author: &AuthorBlueprint{Name: []string{"first author"}}}},
+				})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(1)
+			})
+
+			g.It("does not update or delete books using blueprints that filter by author", func() {
+				blueprint := &BookBlueprint{Author: &AuthorBlueprint{Name: []string{"first author"}}}
+				_, e := store.UpdateBookTitle("updated", blueprint)
+				g.Assert(e == nil).Equal(false)
+				_, e = store.DeleteBooks(blueprint)
+				g.Assert(e == nil).Equal(false)
+				count, e := store.CountBooks(blueprint)
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(1)
+			})
+
+			g.It("does not update or delete books using groups with members that filter by author", func() {
+				blueprint := &BookBlueprint{
+					Or: []*BookBlueprint{{Author: &AuthorBlueprint{Name: []string{"first author"}}}, {ID: []int{3}}},
+				}
+
+				_, e := store.UpdateBookTitle("updated", blueprint)
+				g.Assert(e == nil).Equal(false)
+				_, e = store.DeleteBooks(blueprint)
+				g.Assert(e == nil).Equal(false)
+				count, e := store.CountBooks(blueprint)
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(2)
+			})
+		})
+
+		g.Describe("FindBook", func() {
+			g.It("returns the book matching the primary key", func() {
+				book, e := store.FindBook(2)
+				g.Assert(e).Equal(nil)
+				g.Assert(book.ID).Equal(2)
+				g.Assert(book.Title).Equal("book-2")
+			})
+
+			g.It("returns the not found error if no book matches the primary key", func() {
+				book, e := store.FindBook(-1)
+				g.Assert(e).Equal(ErrBookNotFound)
+				g.Assert(book == nil).Equal(true)
+			})
+
+			g.It("allows the consumer to find a book with a context", func() {
+				book, e := store.FindBookContext(context.Background(), 3)
+				g.Assert(e).Equal(nil)
+				g.Assert(book.ID).Equal(3)
+			})
+		})
+
+		g.Describe("UpdateBook", func() {
+			g.It("writes every column of the book in a single statement", func() {
+				id, e := store.CreateBooks(Book{Title: "Dune", YearPublished: 1965, AuthorID: 1})
+				g.Assert(e).Equal(nil)
+
+				book, e := store.FindBook(int(id))
+				g.Assert(e).Equal(nil)
+
+				book.Title = "Dune Messiah"
+				book.YearPublished = 1969
+				book.SeriesID = sql.NullInt64{Int64: 7, Valid: true}
+
+				count, e := store.UpdateBook(book)
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(int64(1))
+
+				updated, e := store.FindBook(int(id))
+				g.Assert(e).Equal(nil)
+				g.Assert(updated.Title).Equal("Dune Messiah")
+				g.Assert(updated.YearPublished).Equal(1969)
+				g.Assert(updated.SeriesID.Int64).Equal(int64(7))
+			})
+
+			g.It("returns an error if the book is nil", func() {
+				_, e := store.UpdateBook(nil)
+				g.Assert(e == nil).Equal(false)
+			})
+
+			g.It("returns zero if no book matches the primary key", func() {
+				count, e := store.UpdateBook(&Book{ID: -1, Title: "missing"})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(int64(0))
+			})
+		})
+
+		g.Describe("pointer fields", func() {
+			subtitle, pages := "The Fellowship of the Ring", 423
+			series := []string{"pointer-1", "pointer-2", "pointer-3"}
+
+			g.BeforeEach(func() {
+				_, e := store.DeleteBooks(&BookBlueprint{Title: series})
+				g.Assert(e).Equal(nil)
+
+				_, e = store.CreateBooks([]Book{
+					{Title: "pointer-1", YearPublished: 1954, AuthorID: 1, Subtitle: &subtitle, PageCount: &pages},
+					{Title: "pointer-2", YearPublished: 1954, AuthorID: 1},
+					{Title: "pointer-3", YearPublished: 1955, AuthorID: 1, PageCount: &pages},
+				}...)
+				g.Assert(e).Equal(nil)
+			})
+
+			g.It("stores nil values as null & scans them back into nil pointers", func() {
+				books, e := store.FindBooks(&BookBlueprint{Title: series, OrderBy: "title"})
+				g.Assert(e).Equal(nil)
+				g.Assert(len(books)).Equal(3)
+				g.Assert(*books[0].Subtitle).Equal(subtitle)
+				g.Assert(*books[0].PageCount).Equal(pages)
+				g.Assert(books[1].Subtitle == nil).Equal(true)
+				g.Assert(books[1].PageCount == nil).Equal(true)
+			})
+
+			g.It("allows the consumer to find books by null & not null columns", func() {
+				count, e := store.CountBooks(&BookBlueprint{Title: series, Subtitle: []*string{nil}})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(2)
+
+				count, e = store.CountBooks(&BookBlueprint{Title: series, Subtitle: []*string{nil, &subtitle}})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(3)
+
+				count, e = store.CountBooks(&BookBlueprint{Title: series, PageCountNotNull: true})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(2)
+			})
+
+			g.It("allows the consumer to search pointer fields by like & range", func() {
+				count, e := store.CountBooks(&BookBlueprint{SubtitleLike: []string{"%Fellowship%"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(1)
+
+				count, e = store.CountBooks(&BookBlueprint{Title: series, PageCountRange: []int{400, 500}})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(2)
+			})
+
+			g.It("allows the consumer to select the values of pointer fields", func() {
+				subtitles, e := store.SelectBookSubtitles(&BookBlueprint{Title: series, OrderBy: "title"})
+				g.Assert(e).Equal(nil)
+				g.Assert(len(subtitles)).Equal(3)
+				g.Assert(*subtitles[0]).Equal(subtitle)
+				g.Assert(subtitles[1] == nil).Equal(true)
+			})
+
+			g.It("allows the consumer to update pointer fields to null", func() {
+				count, e := store.UpdateBookPageCount(nil, &BookBlueprint{Title: []string{"pointer-3"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(int64(1))
+
+				count, e = store.UpdateBookSubtitle(&subtitle, &BookBlueprint{Title: []string{"pointer-2"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(int64(1))
+
+				remaining, e := store.CountBooks(&BookBlueprint{Title: series, PageCount: []*int{nil}})
+				g.Assert(e).Equal(nil)
+				g.Assert(remaining).Equal(2)
+
+				remaining, e = store.CountBooks(&BookBlueprint{Title: series, SubtitleNotNull: true})
+				g.Assert(e).Equal(nil)
+				g.Assert(remaining).Equal(2)
+			})
+		})
+
+		g.Describe("custom valuer fields", func() {
+			g.BeforeEach(func() {
+				_, e := store.DeleteBooks(&BookBlueprint{TitleLike: []string{"isbn-%"}})
+				g.Assert(e).Equal(nil)
+
+				_, e = store.CreateBooks([]Book{
+					{Title: "isbn-1", YearPublished: 1965, AuthorID: 1, ISBN: NewISBN("9780441013593")},
+					{Title: "isbn-2", YearPublished: 1969, AuthorID: 1, ISBN: NewISBN("9780441172696")},
+					{Title: "isbn-3", YearPublished: 1976, AuthorID: 1, ISBN: NewISBN("0399116974")},
+				}...)
+				g.Assert(e).Equal(nil)
+			})
+
+			g.It("scans the values of the custom type", func() {
+				books, e := store.FindBooks(&BookBlueprint{Title: []string{"isbn-2"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(len(books)).Equal(1)
+				g.Assert(books[0].ISBN.String()).Equal("9780441172696")
+			})
+
+			g.It("allows the consumer to find books by the values of the custom type", func() {
+				titles, e := store.SelectBookTitles(&BookBlueprint{
+					ISBN:    []ISBN{NewISBN("9780441013593"), NewISBN("0399116974")},
+					OrderBy: "title",
+				})
+				g.Assert(e).Equal(nil)
+				g.Assert(titles).Equal([]string{"isbn-1", "isbn-3"})
+			})
+
+			g.It("allows the consumer to search the custom type using like (kind=string)", func() {
+				count, e := store.CountBooks(&BookBlueprint{ISBNLike: []string{"978044%"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(2)
+			})
+		})
+
+		g.Describe("CreateBooksReturning", func() {
+			g.It("returns every primary key in order, assigning them to the books", func() {
+				first := &Book{Title: "The Hobbit", YearPublished: 1937, AuthorID: 1}
+				second := &Book{Title: "The Silmarillion", YearPublished: 1977, AuthorID: 1}
+
+				ids, e := store.CreateBooksReturning(first, second)
+				g.Assert(e).Equal(nil)
+				g.Assert(len(ids)).Equal(2)
+				g.Assert(first.ID).Equal(ids[0])
+				g.Assert(second.ID).Equal(ids[1])
+
+				found, e := store.FindBook(ids[1])
+				g.Assert(e).Equal(nil)
+				g.Assert(found.Title).Equal("The Silmarillion")
+			})
+
+			g.It("returns an empty list if no books", func() {
+				ids, e := store.CreateBooksReturning()
+				g.Assert(e).Equal(nil)
+				g.Assert(len(ids)).Equal(0)
+			})
+
+			g.It("returns an error if one of the books is nil", func() {
+				_, e := store.CreateBooksReturning(nil)
+				g.Assert(e == nil).Equal(false)
+			})
+		})
+
+		g.Describe("UpsertBooks", func() {
+			g.It("inserts books that do not conflict and updates the ones that do", func() {
+				_, e := store.UpsertBooks([]string{"title"}, []Book{
+					{Title: "book-3", YearPublished: 1999, AuthorID: 4},
+					{Title: "Neuromancer", YearPublished: 1984, AuthorID: 5},
+				}...)
+				g.Assert(e).Equal(nil)
+
+				updated, e := store.FindBooks(&BookBlueprint{Title: []string{"book-3"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(len(updated)).Equal(1)
+				g.Assert(updated[0].ID).Equal(3)
+				g.Assert(updated[0].YearPublished).Equal(1999)
+				g.Assert(updated[0].AuthorID).Equal(4)
+
+				inserted, e := store.CountBooks(&BookBlueprint{Title: []string{"Neuromancer"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(inserted).Equal(1)
+			})
+
+			g.It("returns immediately with 0 if no books", func() {
+				count, e := store.UpsertBooks([]string{"title"})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(int64(0))
+			})
+
+			g.It("returns an error without conflict columns", func() {
+				_, e := store.UpsertBooks(nil, Book{Title: "no conflicts"})
+				g.Assert(e == nil).Equal(false)
+			})
+
+			g.It("returns an error if a conflict column is unknown", func() {
+				_, e := store.UpsertBooks([]string{"title; drop table books"}, Book{Title: "bad conflicts"})
+				g.Assert(e == nil).Equal(false)
+			})
+		})
+
 		g.Describe("CreateBooks", func() {
 			g.It("returns immediately with 0 if no authors", func() {
 				s, e := store.CreateBooks()
@@ -380,6 +825,154 @@ func Test_Book(t *testing.T) {
 			})
 		})
 
+		g.Describe("context-aware methods", func() {
+			g.It("allows the consumer to find books with a context", func() {
+				books, e := store.FindBooksContext(context.Background(), &BookBlueprint{ID: []int{1, 2}})
+				g.Assert(e).Equal(nil)
+				g.Assert(len(books)).Equal(2)
+			})
+
+			g.It("returns an error when finding books with a cancelled context", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, e := store.FindBooksContext(ctx, &BookBlueprint{ID: []int{1, 2}})
+				g.Assert(e == nil).Equal(false)
+			})
+
+			g.It("returns an error when counting books with a cancelled context", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, e := store.CountBooksContext(ctx, nil)
+				g.Assert(e == nil).Equal(false)
+			})
+
+			g.It("returns an error when creating books with a cancelled context", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, e := store.CreateBooksContext(ctx, Book{Title: "cancelled"})
+				g.Assert(e == nil).Equal(false)
+			})
+
+			g.It("allows the consumer to update and delete books with a context", func() {
+				ctx := context.Background()
+				id, e := store.CreateBooksContext(ctx, Book{Title: "contextual"})
+				g.Assert(e).Equal(nil)
+				bp := &BookBlueprint{ID: []int{int(id)}}
+
+				updated, e := store.UpdateBookTitleContext(ctx, "contextual (updated)", bp)
+				g.Assert(e).Equal(nil)
+				g.Assert(updated).Equal(int64(1))
+
+				titles, e := store.SelectBookTitlesContext(ctx, bp)
+				g.Assert(e).Equal(nil)
+				g.Assert(titles).Equal([]string{"contextual (updated)"})
+
+				deleted, e := store.DeleteBooksContext(ctx, bp)
+				g.Assert(e).Equal(nil)
+				g.Assert(deleted).Equal(int64(1))
+			})
+		})
+
+		g.Describe("Transaction", func() {
+			var stores *Stores
+
+			g.BeforeEach(func() {
+				stores = NewStores(db, queryLog)
+			})
+
+			g.It("commits the changes made within the block if no error is returned", func() {
+				e := stores.Transaction(func(stores Stores) error {
+					_, e := stores.Books.CreateBooks(Book{Title: "committed book", AuthorID: 1})
+					return e
+				})
+				g.Assert(e).Equal(nil)
+
+				c, e := store.CountBooks(&BookBlueprint{Title: []string{"committed book"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(c).Equal(1)
+			})
+
+			g.It("rolls back the changes made within the block if an error is returned", func() {
+				e := stores.Transaction(func(stores Stores) error {
+					if _, e := stores.Books.CreateBooks(Book{Title: "rolled back book", AuthorID: 1}); e != nil {
+						return e
+					}
+
+					return fmt.Errorf("rollback")
+				})
+				g.Assert(e.Error()).Equal("rollback")
+
+				c, e := store.CountBooks(&BookBlueprint{Title: []string{"rolled back book"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(c).Equal(0)
+			})
+
+			g.It("rolls back the changes made within the block before re-panicking if the block panics", func() {
+				recovered := func() (r interface{}) {
+					defer func() {
+						r = recover()
+					}()
+
+					stores.Transaction(func(stores Stores) error {
+						stores.Books.CreateBooks(Book{Title: "panicked book", AuthorID: 1})
+						panic("rollback")
+					})
+
+					return nil
+				}()
+				g.Assert(recovered).Equal("rollback")
+
+				c, e := store.CountBooks(&BookBlueprint{Title: []string{"panicked book"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(c).Equal(0)
+			})
+
+			g.It("allows multiple stores to share a single transaction", func() {
+				e := stores.Transaction(func(stores Stores) error {
+					aid, e := stores.Authors.CreateAuthors(Author{Name: "transactional author"})
+
+					if e != nil {
+						return e
+					}
+
+					if _, e := stores.Books.CreateBooks(Book{Title: "transactional book", AuthorID: int(aid)}); e != nil {
+						return e
+					}
+
+					return fmt.Errorf("rollback")
+				})
+				g.Assert(e.Error()).Equal("rollback")
+
+				c, e := store.CountBooks(&BookBlueprint{Title: []string{"transactional book"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(c).Equal(0)
+
+				c, e = NewAuthorStore(db, nil).CountAuthors(&AuthorBlueprint{Name: []string{"transactional author"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(c).Equal(0)
+			})
+
+			g.It("allows stores to be bound to a transaction started elsewhere", func() {
+				tx, e := db.Begin()
+				g.Assert(e).Equal(nil)
+
+				_, e = store.WithTx(tx).CreateBooks(Book{Title: "external transaction book", AuthorID: 1})
+				g.Assert(e).Equal(nil)
+
+				e = stores.WithTx(tx).Transaction(func(stores Stores) error {
+					c, e := stores.Books.CountBooks(&BookBlueprint{Title: []string{"external transaction book"}})
+					g.Assert(c).Equal(1)
+					return e
+				})
+				g.Assert(e).Equal(nil)
+				g.Assert(tx.Rollback()).Equal(nil)
+
+				c, e := store.CountBooks(&BookBlueprint{Title: []string{"external transaction book"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(c).Equal(0)
+			})
+		})
+
 		g.Describe("findAuthors", func() {
 			g.It("successfully escapes single quote characters during searches on name", func() {
 				name := "mr astley's blueberries"
@@ -68,13 +68,12 @@ func (db *DatabaseConnections) Initialize() error {
 	return nil
 }
 
-// Stores builds the various model stores generated by marlow.
+// Stores builds the various model stores generated by marlow. Genres are kept in the postgres database; the returned
+// stores begin their transactions on the sqlite database and should not be used with Transaction to update genres.
 func (db *DatabaseConnections) Stores(logger io.Writer) *Stores {
-	return &Stores{
-		Books:   NewBookStore(db.sqlite, logger),
-		Authors: NewAuthorStore(db.sqlite, logger),
-		Genres:  NewGenreStore(db.postgres, logger),
-	}
+	stores := NewStores(db.sqlite, logger)
+	stores.Genres = NewGenreStore(db.postgres, logger)
+	return stores
 }
 
 // Close will attempt to close the open database connections.
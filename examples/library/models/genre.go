@@ -5,10 +5,12 @@ import "database/sql"
 
 // Genre records are used to group and describe a types of books.
 type Genre struct {
-	table    bool          `marlow:"tableName=genres&dialect=postgres&primaryKey=id"`
-	ID       uint          `marlow:"column=id&autoIncrement=true"`
-	Name     string        `marlow:"column=name"`
-	ParentID sql.NullInt64 `marlow:"column=parent_id"`
+	table      bool            `marlow:"tableName=genres&dialect=postgres&primaryKey=id&sharedStores=true"`
+	ID         uint            `marlow:"column=id&autoIncrement=true"`
+	Name       string          `marlow:"column=name"`
+	ParentID   sql.NullInt64   `marlow:"column=parent_id"`
+	References GenreReferences `marlow:"column=genre_references&json"`
+	Tags       []string        `marlow:"column=tags"`
 }
 
 func (g *Genre) String() string {
@@ -0,0 +1,10 @@
+package models
+
+// marlow:ignore
+
+// GenreReferences holds the links describing a genre on other sites. It is stored as a json document in the
+// `genre_references` column; the `json` field flag marshals it when genres are written and unmarshals it when read.
+type GenreReferences struct {
+	Wikipedia string `json:"wikipedia,omitempty"`
+	Goodreads string `json:"goodreads,omitempty"`
+}
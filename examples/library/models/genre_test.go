@@ -82,10 +82,59 @@ func Test_Genre(t *testing.T) {
 					g.Assert(s).Equal("WHERE (genres.id > $1 AND genres.id < $2)")
 				})
 
+				g.It("uses the postgres dialect for blueprint int bound & not in params", func() {
+					min, max := uint(2), uint(10)
+					s := fmt.Sprintf("%s", &GenreBlueprint{
+						ID:    []uint{1},
+						IDGte: &min,
+						IDLt:  &max,
+						IDNot: []uint{4, 5},
+					})
+					g.Assert(s).Equal("WHERE genres.id IN ($1) AND (genres.id >= $2 AND genres.id < $3) AND genres.id NOT IN ($4,$5)")
+				})
+
+				g.It("numbers the placeholders of groups of nested blueprints", func() {
+					s := fmt.Sprintf("%s", &GenreBlueprint{
+						Name: []string{"comedy"},
+						Or:   []*GenreBlueprint{{ID: []uint{1, 2}}, {NameLike: []string{"sci%"}}},
+					})
+					g.Assert(s).Equal("WHERE genres.name IN ($1) AND ((genres.id IN ($2,$3)) OR (genres.name LIKE $4))")
+				})
+
+				g.It("uses the postgres ILIKE operator for blueprint string search params", func() {
+					s := fmt.Sprintf("%s", &GenreBlueprint{NameILike: []string{"sci%"}, NameContains: []string{"fi"}})
+					g.Assert(s).Equal("WHERE genres.name ILIKE $1 AND genres.name ILIKE $2 ESCAPE '!'")
+				})
+
 				g.It("uses the postgres dialect for blueprint string like params", func() {
 					s := fmt.Sprintf("%s", &GenreBlueprint{NameLike: []string{"danny"}})
 					g.Assert(s).Equal("WHERE genres.name LIKE $1")
 				})
+
+				g.It("uses json containment for blueprint json contains params", func() {
+					s := fmt.Sprintf("%s", &GenreBlueprint{
+						ReferencesContains: []GenreReferences{{Wikipedia: "Satire"}},
+						Name:               []string{"comedy"},
+					})
+					g.Assert(s).Equal("WHERE genres.name IN ($1) AND genres.genre_references @> $2")
+				})
+
+				g.It("uses json key equality for blueprint json key params, sorted by key", func() {
+					s := fmt.Sprintf("%s", &GenreBlueprint{
+						ReferencesKey: map[string]string{"wikipedia": "Satire", "goodreads": "satire"},
+					})
+					g.Assert(s).Equal(
+						"WHERE genres.genre_references ->> $1::text = $2 AND genres.genre_references ->> $3::text = $4",
+					)
+				})
+
+				g.It("uses array containment & overlap for blueprint array params", func() {
+					s := fmt.Sprintf("%s", &GenreBlueprint{
+						TagsContains: []string{"classic", "dark"},
+						TagsOverlaps: []string{"funny"},
+					})
+					g.Assert(s).Equal("WHERE genres.tags @> $1 AND genres.tags && $2")
+				})
 			})
 
 			g.It("allows user to create genres", func() {
@@ -106,6 +155,130 @@ func Test_Genre(t *testing.T) {
 				g.Assert(results[0]).Equal("Science Fiction")
 			})
 
+			g.It("allows user to create genres, receiving every primary key in order", func() {
+				comedy, drama := &Genre{Name: "Comedy"}, &Genre{Name: "Drama"}
+				ids, e := store.CreateGenresReturning(comedy, drama)
+				g.Assert(e).Equal(nil)
+				g.Assert(len(ids)).Equal(2)
+				g.Assert(comedy.ID).Equal(ids[0])
+				g.Assert(drama.ID).Equal(ids[1])
+
+				names, e := store.SelectGenreNames(&GenreBlueprint{ID: ids, OrderBy: "id"})
+				g.Assert(e).Equal(nil)
+				g.Assert(names).Equal([]string{"Comedy", "Drama"})
+			})
+
+			g.Describe("json fields", func() {
+				g.BeforeEach(func() {
+					_, e := store.CreateGenres([]Genre{
+						{Name: "Satire", References: GenreReferences{Wikipedia: "Satire", Goodreads: "satire"}},
+						{Name: "Horror", References: GenreReferences{Wikipedia: "Horror_fiction"}},
+						{Name: "Mystery"},
+					}...)
+					g.Assert(e).Equal(nil)
+				})
+
+				g.It("unmarshals the json documents of the found genres", func() {
+					genres, e := store.FindGenres(&GenreBlueprint{Name: []string{"Satire"}})
+					g.Assert(e).Equal(nil)
+					g.Assert(len(genres)).Equal(1)
+					g.Assert(genres[0].References).Equal(GenreReferences{Wikipedia: "Satire", Goodreads: "satire"})
+
+					references, e := store.SelectGenreReferences(&GenreBlueprint{Name: []string{"Horror"}})
+					g.Assert(e).Equal(nil)
+					g.Assert(references).Equal([]GenreReferences{{Wikipedia: "Horror_fiction"}})
+				})
+
+				g.It("allows the consumer to find genres whose json documents contain a document", func() {
+					names, e := store.SelectGenreNames(&GenreBlueprint{
+						ReferencesContains: []GenreReferences{{Goodreads: "satire"}},
+					})
+					g.Assert(e).Equal(nil)
+					g.Assert(names).Equal([]string{"Satire"})
+				})
+
+				g.It("allows the consumer to find genres by the values of their json documents", func() {
+					names, e := store.SelectGenreNames(&GenreBlueprint{
+						ReferencesKey: map[string]string{"wikipedia": "Horror_fiction"},
+					})
+					g.Assert(e).Equal(nil)
+					g.Assert(names).Equal([]string{"Horror"})
+				})
+
+				g.It("allows the consumer to update the json documents of genres", func() {
+					bp := &GenreBlueprint{Name: []string{"Mystery"}}
+					_, e := store.UpdateGenreReferences(GenreReferences{Wikipedia: "Mystery_fiction"}, bp)
+					g.Assert(e).Equal(nil)
+
+					genre, e := store.FindGenre(3)
+					g.Assert(e).Equal(nil)
+					g.Assert(genre.References.Wikipedia).Equal("Mystery_fiction")
+
+					genre.References.Goodreads = "mystery"
+					_, e = store.UpdateGenre(genre)
+					g.Assert(e).Equal(nil)
+
+					count, e := store.CountGenres(&GenreBlueprint{ReferencesKey: map[string]string{"goodreads": "mystery"}})
+					g.Assert(e).Equal(nil)
+					g.Assert(count).Equal(1)
+				})
+			})
+
+			g.Describe("array fields", func() {
+				g.BeforeEach(func() {
+					_, e := store.CreateGenres([]Genre{
+						{Name: "Satire", Tags: []string{"funny", "political"}},
+						{Name: "Horror", Tags: []string{"dark", "classic"}},
+						{Name: "Mystery"},
+					}...)
+					g.Assert(e).Equal(nil)
+				})
+
+				g.It("scans the array columns of the found genres", func() {
+					genres, e := store.FindGenres(&GenreBlueprint{Name: []string{"Horror", "Mystery"}, OrderBy: "id"})
+					g.Assert(e).Equal(nil)
+					g.Assert(len(genres)).Equal(2)
+					g.Assert(genres[0].Tags).Equal([]string{"dark", "classic"})
+					g.Assert(genres[1].Tags == nil).Equal(true)
+
+					tags, e := store.SelectGenreTags(&GenreBlueprint{Name: []string{"Satire"}})
+					g.Assert(e).Equal(nil)
+					g.Assert(tags).Equal([][]string{{"funny", "political"}})
+				})
+
+				g.It("allows the consumer to find genres whose arrays contain each of the elements", func() {
+					names, e := store.SelectGenreNames(&GenreBlueprint{TagsContains: []string{"classic", "dark"}})
+					g.Assert(e).Equal(nil)
+					g.Assert(names).Equal([]string{"Horror"})
+				})
+
+				g.It("allows the consumer to find genres whose arrays have any of the elements", func() {
+					names, e := store.SelectGenreNames(&GenreBlueprint{
+						TagsOverlaps: []string{"dark", "political"},
+						OrderBy:      "id",
+					})
+					g.Assert(e).Equal(nil)
+					g.Assert(names).Equal([]string{"Satire", "Horror"})
+				})
+
+				g.It("allows the consumer to update the arrays of genres", func() {
+					_, e := store.UpdateGenreTags([]string{"puzzling"}, &GenreBlueprint{Name: []string{"Mystery"}})
+					g.Assert(e).Equal(nil)
+
+					genre, e := store.FindGenre(3)
+					g.Assert(e).Equal(nil)
+					g.Assert(genre.Tags).Equal([]string{"puzzling"})
+
+					genre.Tags = append(genre.Tags, "classic")
+					_, e = store.UpdateGenre(genre)
+					g.Assert(e).Equal(nil)
+
+					count, e := store.CountGenres(&GenreBlueprint{TagsContains: []string{"classic"}})
+					g.Assert(e).Equal(nil)
+					g.Assert(count).Equal(2)
+				})
+			})
+
 			g.Describe("having created some genres", func() {
 				var lastID int64
 
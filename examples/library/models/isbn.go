@@ -0,0 +1,43 @@
+package models
+
+import "fmt"
+import "database/sql/driver"
+
+// marlow:ignore
+
+// ISBN is the international standard book number of a book. It is stored as text using the sql.Scanner & driver.Valuer
+// interfaces; the `kind=string` field option lets marlow generate the IN & LIKE lookups of the fields using it.
+type ISBN struct {
+	digits string
+}
+
+// NewISBN returns the ISBN made of the provided digits.
+func NewISBN(digits string) ISBN {
+	return ISBN{digits: digits}
+}
+
+// String returns the digits of the ISBN.
+func (i ISBN) String() string {
+	return i.digits
+}
+
+// Value sends the digits of the ISBN to the database.
+func (i ISBN) Value() (driver.Value, error) {
+	return i.digits, nil
+}
+
+// Scan reads the digits of the ISBN from the database; null columns are read as the empty ISBN.
+func (i *ISBN) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		i.digits = ""
+	case string:
+		i.digits = value
+	case []byte:
+		i.digits = string(value)
+	default:
+		return fmt.Errorf("unable to scan %T into an ISBN", src)
+	}
+
+	return nil
+}
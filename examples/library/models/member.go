@@ -0,0 +1,13 @@
+package models
+
+//go:generate marlowc -input member.go
+
+// Member represents a patron of the library. Members are never removed from the database; deleting them only flags
+// the row using the `deleted_at` column. Concurrent updates are detected using the `version` column.
+type Member struct {
+	table   bool         `marlow:"tableName=members&primaryKey=id&softDelete=deleted_at&upsertable=true"`
+	ID      uint         `marlow:"column=id&autoIncrement=true"`
+	Name    string       `marlow:"column=name"`
+	Status  MemberStatus `marlow:"column=status&enum=active,suspended"`
+	Version int          `marlow:"column=version&version"`
+}
@@ -0,0 +1,6 @@
+package models
+
+// MemberStatus is the standing of a library member. It is stored as a string; the `Status` field of the member record
+// restricts it to the values listed by its `enum` option, generating the MemberStatusActive & MemberStatusSuspended
+// constants alongside the IsValid method of this type.
+type MemberStatus string
@@ -0,0 +1,301 @@
+package models
+
+import "os"
+import "fmt"
+import "time"
+import "errors"
+import "testing"
+import _ "github.com/mattn/go-sqlite3"
+import "database/sql"
+import "github.com/franela/goblin"
+
+func Test_Member(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	dbFile := "member-testing.db"
+
+	g.Describe("MemberBlueprint test suite", func() {
+		g.It("excludes soft deleted members by default", func() {
+			r := fmt.Sprintf("%s", &MemberBlueprint{})
+			g.Assert(r).Equal("WHERE members.deleted_at IS NULL")
+		})
+
+		g.It("groups the blueprint clauses before applying the soft delete scope", func() {
+			r := fmt.Sprintf("%s", &MemberBlueprint{ID: []uint{1, 2}, Name: []string{"ada"}, Inclusive: true})
+			g.Assert(r).Equal("WHERE (members.id IN (?,?) OR members.name IN (?)) AND members.deleted_at IS NULL")
+		})
+
+		g.It("includes soft deleted members when WithDeleted is true", func() {
+			r := fmt.Sprintf("%s", &MemberBlueprint{ID: []uint{1}, WithDeleted: true})
+			g.Assert(r).Equal("WHERE members.id IN (?)")
+		})
+
+		g.It("only includes soft deleted members when OnlyDeleted is true", func() {
+			r := fmt.Sprintf("%s", &MemberBlueprint{OnlyDeleted: true})
+			g.Assert(r).Equal("WHERE members.deleted_at NOT NULL")
+		})
+	})
+
+	g.Describe("Member model & generated store test suite (soft delete)", func() {
+		var db *sql.DB
+		var store MemberStore
+
+		g.BeforeEach(func() {
+			var e error
+			db, e = loadDB(dbFile)
+			g.Assert(e).Equal(nil)
+			store = NewMemberStore(db, nil)
+
+			_, e = store.CreateMembers([]Member{
+				{Name: "ada", Status: MemberStatusActive},
+				{Name: "grace", Status: MemberStatusActive},
+				{Name: "barbara", Status: MemberStatusSuspended},
+			}...)
+			g.Assert(e).Equal(nil)
+
+			count, e := store.DeleteMembers(&MemberBlueprint{Name: []string{"grace"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(int64(1))
+		})
+
+		g.AfterEach(func() {
+			g.Assert(db.Close()).Equal(nil)
+			g.Assert(os.Remove(dbFile)).Equal(nil)
+		})
+
+		g.It("does not remove the row from the table", func() {
+			var count int
+			e := db.QueryRow("select count(*) from members where deleted_at is not null").Scan(&count)
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(1)
+		})
+
+		g.It("excludes soft deleted members when finding w/o a blueprint", func() {
+			members, e := store.FindMembers(nil)
+			g.Assert(e).Equal(nil)
+			g.Assert(len(members)).Equal(2)
+		})
+
+		g.It("excludes soft deleted members when counting w/o a blueprint", func() {
+			count, e := store.CountMembers(nil)
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(2)
+		})
+
+		g.It("excludes soft deleted members when selecting w/o a blueprint", func() {
+			names, e := store.SelectMemberNames(nil)
+			g.Assert(e).Equal(nil)
+			g.Assert(len(names)).Equal(2)
+		})
+
+		g.It("returns the not found error when looking up a soft deleted member", func() {
+			_, e := store.FindMember(2)
+			g.Assert(e).Equal(ErrMemberNotFound)
+		})
+
+		g.It("allows the consumer to include soft deleted members", func() {
+			count, e := store.CountMembers(&MemberBlueprint{WithDeleted: true})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(3)
+		})
+
+		g.It("allows the consumer to find only the soft deleted members", func() {
+			members, e := store.FindMembers(&MemberBlueprint{OnlyDeleted: true})
+			g.Assert(e).Equal(nil)
+			g.Assert(len(members)).Equal(1)
+			g.Assert(members[0].Name).Equal("grace")
+		})
+
+		g.It("flags the deleted members using the current time of the store", func() {
+			deletedAt := time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+			clockStore := store.WithClock(func() time.Time { return deletedAt })
+
+			count, e := clockStore.DeleteMembers(&MemberBlueprint{Name: []string{"ada"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(int64(1))
+
+			var flagged time.Time
+			e = db.QueryRow("select deleted_at from members where name = 'ada'").Scan(&flagged)
+			g.Assert(e).Equal(nil)
+			g.Assert(flagged.Equal(deletedAt)).Equal(true)
+		})
+
+		g.It("does not delete members that have already been soft deleted", func() {
+			count, e := store.DeleteMembers(&MemberBlueprint{Name: []string{"grace"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(int64(0))
+		})
+
+		g.It("allows the consumer to find members by a named string type field", func() {
+			members, e := store.FindMembers(&MemberBlueprint{Status: []MemberStatus{MemberStatusSuspended}})
+			g.Assert(e).Equal(nil)
+			g.Assert(len(members)).Equal(1)
+			g.Assert(members[0].Name).Equal("barbara")
+			g.Assert(members[0].Status).Equal(MemberStatusSuspended)
+		})
+
+		g.It("allows the consumer to search members using like on a named string type field", func() {
+			count, e := store.CountMembers(&MemberBlueprint{StatusLike: []string{"act%"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(1)
+		})
+
+		g.It("returns an error when deleting w/o any clauses", func() {
+			_, e := store.DeleteMembers(&MemberBlueprint{WithDeleted: true})
+			g.Assert(e == nil).Equal(false)
+		})
+
+		g.Describe("enum fields", func() {
+			g.It("generates the constants & validation of the listed values", func() {
+				g.Assert(string(MemberStatusSuspended)).Equal("suspended")
+				g.Assert(MemberStatusActive.IsValid()).Equal(true)
+				g.Assert(MemberStatus("bogus").IsValid()).Equal(false)
+			})
+
+			g.It("does not create members with unknown values", func() {
+				_, e := store.CreateMembers(Member{Name: "linus", Status: MemberStatusActive}, Member{Name: "ken"})
+				g.Assert(e == nil).Equal(false)
+
+				_, e = store.CreateMembersReturning(&Member{Name: "ken", Status: MemberStatus("bogus")})
+				g.Assert(e == nil).Equal(false)
+
+				count, e := store.CountMembers(&MemberBlueprint{Name: []string{"linus", "ken"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(0)
+			})
+
+			g.It("does not update members with unknown values", func() {
+				_, e := store.UpdateMemberStatus(MemberStatus("bogus"), 0, &MemberBlueprint{ID: []uint{1}})
+				g.Assert(e == nil).Equal(false)
+
+				member, e := store.FindMember(1)
+				g.Assert(e).Equal(nil)
+				member.Status = MemberStatus("bogus")
+				_, e = store.UpdateMember(member)
+				g.Assert(e == nil).Equal(false)
+
+				found, e := store.FindMember(1)
+				g.Assert(e).Equal(nil)
+				g.Assert(found.Status).Equal(MemberStatusActive)
+				g.Assert(found.Version).Equal(0)
+			})
+
+			g.It("allows the consumer to update members with valid values", func() {
+				count, e := store.UpdateMemberStatus(MemberStatusSuspended, 0, &MemberBlueprint{ID: []uint{1}})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(int64(1))
+			})
+		})
+
+		g.Describe("optimistic locking", func() {
+			g.It("increments the version of the record when updating the whole record", func() {
+				member, e := store.FindMember(1)
+				g.Assert(e).Equal(nil)
+				member.Name = "ada lovelace"
+				count, e := store.UpdateMember(member)
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(int64(1))
+				g.Assert(member.Version).Equal(1)
+
+				found, e := store.FindMember(1)
+				g.Assert(e).Equal(nil)
+				g.Assert(found.Name).Equal("ada lovelace")
+				g.Assert(found.Version).Equal(1)
+			})
+
+			g.It("returns the stale error when the record has been upserted since it was loaded", func() {
+				member, e := store.FindMember(1)
+				g.Assert(e).Equal(nil)
+
+				count, e := store.UpsertMembers([]string{"name"}, Member{Name: "ada", Status: MemberStatusSuspended})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(int64(1))
+
+				member.Name = "ada lovelace"
+				count, e = store.UpdateMember(member)
+				g.Assert(e).Equal(ErrStaleMember)
+				g.Assert(count).Equal(int64(0))
+
+				found, e := store.FindMember(1)
+				g.Assert(e).Equal(nil)
+				g.Assert(found.Name).Equal("ada")
+				g.Assert(found.Status).Equal(MemberStatusSuspended)
+				g.Assert(found.Version).Equal(1)
+			})
+
+			g.It("returns the stale error when the record has been updated since it was loaded", func() {
+				first, e := store.FindMember(1)
+				g.Assert(e).Equal(nil)
+				second, e := store.FindMember(1)
+				g.Assert(e).Equal(nil)
+
+				first.Name = "first"
+				_, e = store.UpdateMember(first)
+				g.Assert(e).Equal(nil)
+
+				second.Name = "second"
+				count, e := store.UpdateMember(second)
+				g.Assert(e).Equal(ErrStaleMember)
+				g.Assert(errors.Is(e, ErrStaleRecord)).Equal(true)
+				g.Assert(count).Equal(int64(0))
+				g.Assert(second.Version).Equal(0)
+
+				found, e := store.FindMember(1)
+				g.Assert(e).Equal(nil)
+				g.Assert(found.Name).Equal("first")
+			})
+
+			g.It("only updates single fields of rows at the expected version", func() {
+				blueprint := &MemberBlueprint{ID: []uint{1}}
+				count, e := store.UpdateMemberName("ada lovelace", 0, blueprint)
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(int64(1))
+
+				count, e = store.UpdateMemberName("ada king", 0, blueprint)
+				g.Assert(e).Equal(ErrStaleMember)
+				g.Assert(count).Equal(int64(0))
+
+				found, e := store.FindMember(1)
+				g.Assert(e).Equal(nil)
+				g.Assert(found.Name).Equal("ada lovelace")
+				g.Assert(found.Version).Equal(1)
+			})
+
+			g.It("returns an error when updating single fields w/o a limiting blueprint", func() {
+				_, e := store.UpdateMemberName("updated", 0, nil)
+				g.Assert(e == nil).Equal(false)
+
+				_, e = store.UpdateMemberName("updated", 0, &MemberBlueprint{})
+				g.Assert(e == nil).Equal(false)
+
+				found, e := store.FindMember(1)
+				g.Assert(e).Equal(nil)
+				g.Assert(found.Version).Equal(0)
+			})
+
+			g.It("groups inclusive blueprints before applying the version clause", func() {
+				blueprint := &MemberBlueprint{ID: []uint{1}, Name: []string{"barbara"}, Inclusive: true}
+				count, e := store.UpdateMemberStatus(MemberStatusSuspended, 0, blueprint)
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(int64(2))
+			})
+		})
+
+		g.Describe("RestoreMembers", func() {
+			g.It("returns an error when restoring w/o any clauses", func() {
+				_, e := store.RestoreMembers(&MemberBlueprint{})
+				g.Assert(e == nil).Equal(false)
+			})
+
+			g.It("only restores the members that match the blueprint", func() {
+				count, e := store.RestoreMembers(&MemberBlueprint{Name: []string{"grace", "ada"}})
+				g.Assert(e).Equal(nil)
+				g.Assert(count).Equal(int64(1))
+
+				members, e := store.FindMembers(nil)
+				g.Assert(e).Equal(nil)
+				g.Assert(len(members)).Equal(3)
+			})
+		})
+	})
+}
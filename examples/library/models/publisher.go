@@ -0,0 +1,17 @@
+package models
+
+import "fmt"
+
+// Publisher records are stored in mysql and represent the companies that print books. The `key` column is a reserved
+// word in mysql, which marlow handles by quoting every identifier with backticks.
+type Publisher struct {
+	table   bool   `marlow:"tableName=publishers&dialect=mysql&primaryKey=id&upsertable=true"`
+	ID      uint   `marlow:"column=id&autoIncrement=true"`
+	Name    string `marlow:"column=name"`
+	Key     string `marlow:"column=key"`
+	Founded int    `marlow:"column=founded&updateable=false"`
+}
+
+func (p *Publisher) String() string {
+	return fmt.Sprintf("%s (%d)", p.Name, p.Founded)
+}
@@ -0,0 +1,141 @@
+package models
+
+import "os"
+import "fmt"
+import "bytes"
+import "testing"
+import "net"
+import "database/sql"
+import _ "github.com/go-sql-driver/mysql"
+import "github.com/franela/goblin"
+import "github.com/dolthub/go-mysql-server/memory"
+import "github.com/dolthub/go-mysql-server/server"
+import sqle "github.com/dolthub/go-mysql-server"
+import gms "github.com/dolthub/go-mysql-server/sql"
+import "github.com/dadleyy/marlow/examples/library/data"
+
+func Test_Publisher(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("publisher record test suite (mysql)", func() {
+		var db *sql.DB
+		var store PublisherStore
+		var queryLog *bytes.Buffer
+		var mysqlServer *server.Server
+
+		// The suite runs against an in-process, mysql wire compatible server backed by an in-memory database.
+		g.Before(func() {
+			listener, e := net.Listen("tcp", "127.0.0.1:0")
+			g.Assert(e).Equal(nil)
+
+			provider := memory.NewDBProvider(memory.NewDatabase("marlow_test"))
+			engine, sessions := sqle.NewDefault(provider), memory.NewSessionBuilder(provider)
+			mysqlServer, e = server.NewServer(server.Config{Listener: listener}, engine, gms.NewContext, sessions, nil)
+			g.Assert(e).Equal(nil)
+
+			go mysqlServer.Start()
+
+			db, e = sql.Open("mysql", fmt.Sprintf("root@tcp(%s)/marlow_test?multiStatements=true", listener.Addr()))
+			g.Assert(e).Equal(nil)
+		})
+
+		g.After(func() {
+			g.Assert(db.Close()).Equal(nil)
+			g.Assert(mysqlServer.Close()).Equal(nil)
+		})
+
+		g.BeforeEach(func() {
+			schema, e := data.Asset("data/mysql.sql")
+			g.Assert(e).Equal(nil)
+			_, e = db.Exec(string(schema))
+			g.Assert(e).Equal(nil)
+			queryLog = new(bytes.Buffer)
+			store = NewPublisherStore(db, queryLog)
+
+			_, e = store.CreatePublishers([]Publisher{
+				{Name: "Penguin", Key: "penguin", Founded: 1935},
+				{Name: "Vintage", Key: "vintage", Founded: 1954},
+				{Name: "Tor", Key: "tor", Founded: 1980},
+			}...)
+			g.Assert(e).Equal(nil)
+		})
+
+		g.AfterEach(func() {
+			fmt.Fprintf(os.Stdout, "%s", queryLog.String())
+		})
+
+		g.It("quotes identifiers with backticks", func() {
+			_, e := store.FindPublishers(&PublisherBlueprint{Key: []string{"tor"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(bytes.Contains(queryLog.Bytes(), []byte("`publishers`.`key`"))).Equal(true)
+		})
+
+		g.It("allows the consumer to find publishers by a reserved word column", func() {
+			publishers, e := store.FindPublishers(&PublisherBlueprint{Key: []string{"penguin", "tor"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(len(publishers)).Equal(2)
+		})
+
+		g.It("performs case sensitive like comparisons", func() {
+			publishers, e := store.FindPublishers(&PublisherBlueprint{NameLike: []string{"%enguin"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(len(publishers)).Equal(1)
+
+			publishers, e = store.FindPublishers(&PublisherBlueprint{NameLike: []string{"%ENGUIN"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(len(publishers)).Equal(0)
+		})
+
+		g.It("allows the consumer to count and select publishers", func() {
+			count, e := store.CountPublishers(&PublisherBlueprint{FoundedRange: []int{1950, 2000}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(2)
+
+			keys, e := store.SelectPublisherKeys(&PublisherBlueprint{Founded: []int{1935}})
+			g.Assert(e).Equal(nil)
+			g.Assert(keys).Equal([]string{"penguin"})
+		})
+
+		g.It("allows the consumer to update a reserved word column", func() {
+			_, e := store.UpdatePublisherKey("penguin-books", &PublisherBlueprint{Key: []string{"penguin"}})
+			g.Assert(e).Equal(nil)
+			count, e := store.CountPublishers(&PublisherBlueprint{Key: []string{"penguin-books"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(1)
+		})
+
+		g.It("allows the consumer to update a whole publisher, skipping columns that are not updateable", func() {
+			publisher, e := store.FindPublisher(1)
+			g.Assert(e).Equal(nil)
+			publisher.Name = "Penguin Books"
+			publisher.Founded = 2000
+			count, e := store.UpdatePublisher(publisher)
+			g.Assert(e).Equal(nil)
+			g.Assert(count).Equal(int64(1))
+
+			publisher, e = store.FindPublisher(1)
+			g.Assert(e).Equal(nil)
+			g.Assert(publisher.Name).Equal("Penguin Books")
+			g.Assert(publisher.Founded).Equal(1935)
+		})
+
+		g.It("allows the consumer to upsert publishers using the unique key column", func() {
+			_, e := store.UpsertPublishers([]string{"key"}, []Publisher{
+				{Name: "Tor Books", Key: "tor", Founded: 1980},
+				{Name: "Orbit", Key: "orbit", Founded: 1974},
+			}...)
+			g.Assert(e).Equal(nil)
+			g.Assert(bytes.Contains(queryLog.Bytes(), []byte("ON DUPLICATE KEY UPDATE"))).Equal(true)
+
+			names, e := store.SelectPublisherNames(&PublisherBlueprint{Key: []string{"tor", "orbit"}, OrderBy: "name"})
+			g.Assert(e).Equal(nil)
+			g.Assert(names).Equal([]string{"Orbit", "Tor Books"})
+		})
+
+		g.It("allows the consumer to delete publishers", func() {
+			deleted, e := store.DeletePublishers(&PublisherBlueprint{Key: []string{"tor"}})
+			g.Assert(e).Equal(nil)
+			g.Assert(deleted).Equal(int64(1))
+		})
+	})
+}
@@ -1,15 +1,48 @@
 module github.com/dadleyy/marlow
 
+go 1.23.3
+
 require (
-	github.com/VividCortex/ewma v0.0.0-20170804035156-43880d236f69
+	github.com/dolthub/go-mysql-server v0.20.0
 	github.com/dustin/go-humanize v0.0.0-20171111073723-bb3d318650d4
-	github.com/franela/goblin v0.0.0-20180407132755-cd5d08fb4ede
+	github.com/franela/goblin v0.0.0-20200105215937-c9ffbefa60db
 	github.com/gedex/inflector v0.0.0-20161103042756-046f2c312046
+	github.com/go-sql-driver/mysql v1.7.2-0.20231213112541-0004702b931d
 	github.com/lib/pq v0.0.0-20171022192043-b609790bd85e
-	github.com/mattn/go-isatty v0.0.0-20151211000621-56b76bdf51f7
-	github.com/mattn/go-sqlite3 v1.2.0
+	github.com/mattn/go-sqlite3 v1.14.6
 	github.com/vbauerster/mpb v3.3.2+incompatible
-	golang.org/x/crypto v0.0.0-20161019071413-c367d6eeb7c6
-	golang.org/x/net v0.0.0-20160629225444-8e573f4005aa
-	golang.org/x/sys v0.0.0-20160615012701-62bee0375999
+)
+
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/VividCortex/ewma v0.0.0-20170804035156-43880d236f69 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/dolthub/flatbuffers/v23 v23.3.3-dh.2 // indirect
+	github.com/dolthub/go-icu-regex v0.0.0-20250327004329-6799764f2dad // indirect
+	github.com/dolthub/jsonpath v0.0.2-0.20240227200619-19675ab05c71 // indirect
+	github.com/dolthub/vitess v0.0.0-20250512224608-8fb9c6ea092c // indirect
+	github.com/go-kit/kit v0.10.0 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/google/uuid v1.3.0 // indirect
+	github.com/hashicorp/golang-lru v0.5.4 // indirect
+	github.com/lestrrat-go/strftime v1.0.4 // indirect
+	github.com/mattn/go-isatty v0.0.4 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/shopspring/decimal v1.3.1 // indirect
+	github.com/sirupsen/logrus v1.8.1 // indirect
+	github.com/tetratelabs/wazero v1.8.2 // indirect
+	go.opentelemetry.io/otel v1.31.0 // indirect
+	go.opentelemetry.io/otel/trace v1.31.0 // indirect
+	golang.org/x/crypto v0.13.0 // indirect
+	golang.org/x/mod v0.12.0 // indirect
+	golang.org/x/sync v0.3.0 // indirect
+	golang.org/x/sys v0.12.0 // indirect
+	golang.org/x/term v0.12.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+	golang.org/x/tools v0.13.0 // indirect
+	google.golang.org/genproto v0.0.0-20230110181048-76db0878b65f // indirect
+	google.golang.org/grpc v1.53.0 // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
+	gopkg.in/src-d/go-errors.v1 v1.0.0 // indirect
 )
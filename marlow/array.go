@@ -0,0 +1,115 @@
+package marlow
+
+import "io"
+import "fmt"
+import "strings"
+import "net/url"
+import "github.com/dadleyy/marlow/marlow/writing"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+// arrayElementTypes are the element types of the slice fields mapping array columns; the types encoded & scanned by
+// the pq.Array function without the elements implementing sql.Scanner.
+var arrayElementTypes = []string{"string", "int64", "float64", "bool"}
+
+// arrayField returns true for slice fields, mapping the array columns of the dialects that support them. The values of
+// json fields are stored as json documents instead.
+func arrayField(config url.Values) bool {
+	return strings.HasPrefix(config.Get("type"), "[]") && !jsonField(config)
+}
+
+// parseArray validates the element type of an array field against the array support of the record's dialect.
+func parseArray(record marlowRecord, name string, config url.Values) error {
+	if record.dialect().ArrayContains(name) == "" {
+		return fmt.Errorf("slice types not supported by marlow, field: %s", name)
+	}
+
+	fieldType := config.Get("type")
+
+	for _, t := range arrayElementTypes {
+		if fieldType == fmt.Sprintf("[]%s", t) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("array columns must be []string, []int64, []float64 or []bool, %s has type \"%s\"", name, fieldType)
+}
+
+// arrayMethods returns the readers generating the array clause methods of an array field.
+func arrayMethods(record marlowRecord, fieldName string, fieldConfig url.Values, methods chan<- string) []io.Reader {
+	return []io.Reader{
+		arrayClause(record, fieldName, fieldConfig, methods, arrayContains),
+		arrayClause(record, fieldName, fieldConfig, methods, arrayOverlaps),
+	}
+}
+
+// arrayOperation holds the names & dialect clause of one of the lookups generated for array fields.
+type arrayOperation struct {
+	method       string
+	suffixOption string
+	clause       func(Dialect, string) string
+}
+
+var arrayContains = arrayOperation{
+	method:       "Contains",
+	suffixOption: constants.BlueprintArrayContainsFieldSuffixConfigOption,
+	clause:       Dialect.ArrayContains,
+}
+
+var arrayOverlaps = arrayOperation{
+	method:       "Overlaps",
+	suffixOption: constants.BlueprintArrayOverlapsFieldSuffixConfigOption,
+	clause:       Dialect.ArrayOverlaps,
+}
+
+// arrayClause generates the clause method matching the rows whose array column is compared to the elements of one of
+// the array lookups of the field; the elements are sent as a single array value.
+func arrayClause(
+	record marlowRecord,
+	fieldName string,
+	fieldConfig url.Values,
+	methods chan<- string,
+	operation arrayOperation,
+) io.Reader {
+	pr, pw := io.Pipe()
+	columnName := fieldConfig.Get(constants.ColumnConfigOption)
+	methodName := fmt.Sprintf("%s%sString", columnName, operation.method)
+	lookupName := fmt.Sprintf("%s%s", fieldName, record.config.Get(operation.suffixOption))
+	columnReference := record.columnReference(columnName)
+
+	symbols := struct {
+		count string
+	}{"_count"}
+
+	returns := []string{"string", "[]interface{}"}
+	params := []writing.FuncParam{
+		{Type: "int", Symbol: symbols.count},
+	}
+
+	write := func() {
+		writer := writing.NewGoWriter(pw)
+		writer.Comment("[marlow] array %s clause for \"%s\"", strings.ToLower(operation.method), columnReference)
+
+		e := writer.WithMethod(methodName, record.blueprint(), params, returns, func(scope url.Values) error {
+			lookup := fmt.Sprintf("%s.%s", scope.Get("receiver"), lookupName)
+
+			writer.WithIf("len(%s) == 0", func(url.Values) error {
+				return writer.Returns(writing.EmptyString, writing.Nil)
+			}, lookup)
+
+			placeholder := record.dialect().Placeholder(symbols.count)
+			clause := sqlExpression(operation.clause(record.dialect(), columnReference), placeholder)
+			values := fmt.Sprintf("[]interface{}{pq.Array(%s)}", lookup)
+			return writer.Returns(clause, values)
+		})
+
+		if e == nil {
+			methods <- methodName
+		}
+
+		pw.CloseWithError(e)
+	}
+
+	go write()
+
+	return pr
+}
@@ -4,6 +4,7 @@ import "io"
 import "fmt"
 import "sync"
 import "strings"
+import "strconv"
 import "net/url"
 import "go/types"
 import "github.com/dadleyy/marlow/marlow/writing"
@@ -20,31 +21,108 @@ func writeBlueprint(destination io.Writer, record marlowRecord) error {
 				return fmt.Errorf("bad field type for field name: %s", name)
 			}
 
-			typeInfo := getTypeInfo(fieldType)
+			typeInfo := fieldTypeInfo(config)
+
+			if fieldImport := config.Get("import"); fieldImport != "" {
+				record.registerImports(fieldImport)
+			}
+
+			// Json fields only support the json lookups of the dialect (if any).
+			if jsonField(config) {
+				if record.dialect().JSONContains(name) != "" {
+					containsSuffix := record.config.Get(constants.BlueprintJSONContainsFieldSuffixConfigOption)
+					out.Println("%s%s []%s", name, containsSuffix, fieldType)
+					out.Println("%s%s map[string]string", name, record.config.Get(constants.BlueprintJSONKeyFieldSuffixConfigOption))
+				}
+
+				continue
+			}
+
+			// Array fields are matched by the elements of the array, sent as a single array value.
+			if arrayField(config) {
+				record.registerImports("github.com/lib/pq")
+				out.Println("%s%s %s", name, record.config.Get(constants.BlueprintArrayContainsFieldSuffixConfigOption), fieldType)
+				out.Println("%s%s %s", name, record.config.Get(constants.BlueprintArrayOverlapsFieldSuffixConfigOption), fieldType)
+				continue
+			}
 
 			// Support IN lookup on string fields.
 			if typeInfo&types.IsNumeric != 0 {
-				out.Println("%s%s []%s", name, record.config.Get(constants.BlueprintRangeFieldSuffixConfigOption), fieldType)
+				rangeSuffix := record.config.Get(constants.BlueprintRangeFieldSuffixConfigOption)
+				valueType := fieldValueType(config)
+				out.Println("%s%s []%s", name, rangeSuffix, valueType)
+
+				// Bounds are optional; nil pointers leave the column unbounded.
+				for _, bound := range blueprintBounds {
+					out.Println("%s%s *%s", name, record.config.Get(bound.suffixOption), valueType)
+				}
+
+				out.Println("%s%s []%s", name, record.config.Get(constants.BlueprintNotFieldSuffixConfigOption), valueType)
+
+				// The value type of sql.NullTime fields is not necessarily imported by the source.
+				if _, ok := sqlNullField(config); ok && strings.HasPrefix(valueType, "time.") {
+					record.registerImports("time")
+				}
 			}
 
 			// Support LIKE lookup on string fields.
 			if typeInfo&types.IsString != 0 {
 				out.Println("%s%s []string", name, record.config.Get(constants.BlueprintLikeFieldSuffixConfigOption))
+
+				for _, search := range blueprintSearches {
+					out.Println("%s%s []string", name, record.config.Get(search.suffixOption))
+				}
 			}
 
-			if fieldImport := config.Get("import"); fieldImport != "" {
-				record.registerImports(fieldImport)
+			// Nullable fields can be used to match the rows whose column is not null.
+			if nullableField(config) {
+				out.Println("%s%s bool", name, record.config.Get(constants.BlueprintNotNullFieldSuffixConfigOption))
 			}
 
 			out.Println("%s []%s", name, fieldType)
 		}
 
+		// Joined relations accept a blueprint of the referenced record, filtering by the columns of the joined table.
+		relations := make(map[string]string)
+
+		for _, rel := range record.joins() {
+			if _, dupe := record.fields[rel.name]; dupe {
+				return fmt.Errorf("relation name %s of field %s conflicts with the field of the same name", rel.name, rel.field)
+			}
+
+			if other, dupe := relations[rel.name]; dupe {
+				return fmt.Errorf("relation name %s is used by both %s & %s", rel.name, other, rel.field)
+			}
+
+			relations[rel.name] = rel.field
+			out.Println("%s *%s", rel.name, rel.blueprint())
+		}
+
+		// Groups nest blueprints of the record itself, each rendered as a parenthesized clause.
+		for _, group := range blueprintGroups {
+			if _, dupe := record.fields[group.field]; dupe {
+				return fmt.Errorf("blueprint group %s conflicts with the field of the same name", group.field)
+			}
+
+			if field, dupe := relations[group.field]; dupe {
+				return fmt.Errorf("blueprint group %s conflicts with the relation name of %s", group.field, field)
+			}
+
+			out.Println("%s []*%s", group.field, record.blueprint())
+		}
+
 		out.Println("Inclusive bool")
 		out.Println("Limit int")
 		out.Println("Offset int")
 		out.Println("OrderBy string")
 		out.Println("OrderDirection string")
 
+		// Soft deleted records are excluded unless the consumer explicitly asks for them.
+		if record.softDeleteColumn() != "" {
+			out.Println("WithDeleted bool")
+			out.Println("OnlyDeleted bool")
+		}
+
 		return nil
 	})
 
@@ -80,6 +158,16 @@ func writeBlueprint(destination io.Writer, record marlowRecord) error {
 		readers = append(readers, fieldGenerators...)
 	}
 
+	// The clauses of the nested relation blueprints follow the clauses of the record's own fields.
+	for _, rel := range record.joins() {
+		readers = append(readers, relationMethods(record, rel, methodReceiver))
+	}
+
+	// The groups of nested blueprints are last; their placeholders continue the numbering of every other clause.
+	for _, group := range blueprintGroups {
+		readers = append(readers, groupMethods(record, group, methodReceiver))
+	}
+
 	if _, e := io.Copy(destination, io.MultiReader(readers...)); e != nil {
 		return e
 	}
@@ -93,14 +181,19 @@ func writeBlueprint(destination io.Writer, record marlowRecord) error {
 		clauseItem  string
 		valueCount  string
 		values      string
-	}{"_map", "_clauses", "_item", "_count", "_values"}
+		where       string
+		scope       string
+	}{"_map", "_clauses", "_item", "_count", "_values", "_where", "_scope"}
+
+	countParams := []writing.FuncParam{
+		{Type: "int", Symbol: symbols.valueCount},
+	}
 
 	// With all of our fields having generated non-exported clause generation methods on our struct, we can create the
-	// final 'String' method which iterates over all of these, calling them and adding the non-empty string clauses to
-	// a list, which eventually is returned as a joined string.
-	e = out.WithMethod("String", record.blueprint(), nil, []string{"string"}, func(scope url.Values) error {
+	// 'where' method which iterates over all of these, calling them and adding the non-empty string clauses to a list,
+	// which eventually is returned as a joined string. Placeholders are numbered starting at the provided count.
+	e = out.WithMethod("where", record.blueprint(), countParams, []string{"string"}, func(scope url.Values) error {
 		out.Println("%s := make([]string, 0, %d)", symbols.clauseSlice, len(clauseMethods))
-		out.Println("%s := 1", symbols.valueCount)
 
 		for _, method := range clauseMethods {
 			out.WithIf("%s, %s := %s.%s(%s); %s != \"\"", func(url.Values) error {
@@ -120,14 +213,66 @@ func writeBlueprint(destination io.Writer, record marlowRecord) error {
 			return out.Println("%s = \" OR \"", symbols.clauseMap)
 		}, scope.Get("receiver"))
 
-		return out.Returns(fmt.Sprintf("\"WHERE \" + strings.Join(%s, %s)", symbols.clauseSlice, symbols.clauseMap))
+		return out.Returns(fmt.Sprintf("strings.Join(%s, %s)", symbols.clauseSlice, symbols.clauseMap))
+	})
+
+	if e != nil {
+		return e
+	}
+
+	// The 'clause' method combines the where clauses with the soft delete scope of the record (if any). It is also used
+	// by the blueprints of referencing records, which merge it into their own clauses when joining the record's table.
+	e = out.WithMethod("clause", record.blueprint(), countParams, []string{"string"}, func(scope url.Values) error {
+		receiver := scope.Get("receiver")
+		column := record.softDeleteColumn()
+
+		if column == "" {
+			return out.Returns(fmt.Sprintf("%s.where(%s)", receiver, symbols.valueCount))
+		}
+
+		reference := record.columnReference(column)
+		out.Println("%s := %s.where(%s)", symbols.where, receiver, symbols.valueCount)
+		out.Println("%s := \"%s IS NULL\"", symbols.scope, reference)
+
+		out.WithIf("%s.WithDeleted == true", func(url.Values) error {
+			return out.Println("%s = \"\"", symbols.scope)
+		}, receiver)
+
+		out.WithIf("%s.OnlyDeleted == true", func(url.Values) error {
+			return out.Println("%s = %s", symbols.scope, strconv.Quote(record.dialect().NotNull(reference)))
+		}, receiver)
+
+		out.WithIf("%s != \"\" && %s != \"\"", func(url.Values) error {
+			return out.Returns(fmt.Sprintf("fmt.Sprintf(\"(%%s) AND %%s\", %s, %s)", symbols.where, symbols.scope))
+		}, symbols.where, symbols.scope)
+
+		out.WithIf("%s != \"\"", func(url.Values) error {
+			return out.Returns(symbols.scope)
+		}, symbols.scope)
+
+		return out.Returns(symbols.where)
+	})
+
+	if e != nil {
+		return e
+	}
+
+	// The 'String' method produces the full WHERE clause.
+	e = out.WithMethod("String", record.blueprint(), nil, []string{"string"}, func(scope url.Values) error {
+		out.Println("%s := %s.clause(1)", symbols.where, scope.Get("receiver"))
+
+		out.WithIf("%s == \"\"", func(url.Values) error {
+			return out.Returns(writing.EmptyString)
+		}, symbols.where)
+
+		return out.Returns(fmt.Sprintf("\"WHERE \" + %s", symbols.where))
 	})
 
 	if e != nil {
 		return e
 	}
 
-	return out.WithMethod("Values", record.blueprint(), nil, []string{"[]interface{}"}, func(scope url.Values) error {
+	e = out.WithMethod("Values", record.blueprint(), nil, []string{"[]interface{}"}, func(scope url.Values) error {
 		out.Println("%s := make([]interface{}, 0, %d)", symbols.clauseSlice, len(clauseMethods))
 
 		out.WithIf("%s == nil", func(url.Values) error {
@@ -142,29 +287,207 @@ func writeBlueprint(destination io.Writer, record marlowRecord) error {
 
 		return out.Returns(symbols.clauseSlice)
 	})
+
+	if e != nil {
+		return e
+	}
+
+	if e := writeBlueprintOrder(out, record); e != nil {
+		return e
+	}
+
+	return writeBlueprintJoins(out, record)
+}
+
+// writeBlueprintJoins adds the methods used to join the tables of related records into lookups. The 'join' method is
+// called by the blueprints of referencing records, which only know the name of the referenced field, while the 'joins'
+// method produces the JOIN clauses for each of the nested relation blueprints that were provided, either by the
+// blueprint itself or by the members of its groups. Each related table is joined once; the nested relation blueprints
+// of every member are grouped into a single blueprint of the referenced record, joining their own relations.
+func writeBlueprintJoins(out writing.GoWriter, record marlowRecord) error {
+	symbols := struct {
+		field     string
+		reference string
+		columns   string
+		joins     string
+		members   string
+		member    string
+	}{"_field", "_reference", "_columns", "_joins", "_members", "_member"}
+
+	fields := record.fieldList(nil)
+	columns := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		columns = append(columns, fmt.Sprintf("\"%s\": \"%s\"", f.name, f.column))
+	}
+
+	params := []writing.FuncParam{
+		{Type: "string", Symbol: symbols.field},
+		{Type: "string", Symbol: symbols.reference},
+	}
+
+	out.Comment("[marlow] join clauses for \"%s\"", record.table())
+
+	e := out.WithMethod("join", record.blueprint(), params, []string{"string"}, func(scope url.Values) error {
+		format := strconv.Quote(fmt.Sprintf(" JOIN %s ON %%s = %%s%%s", record.quote(record.table())))
+		out.Println("%s := map[string]string{%s}", symbols.columns, strings.Join(columns, ","))
+
+		return out.Returns(fmt.Sprintf(
+			"fmt.Sprintf(%s, %s[%s], %s, %s.joins())",
+			format,
+			symbols.columns,
+			symbols.field,
+			symbols.reference,
+			scope.Get("receiver"),
+		))
+	})
+
+	if e != nil {
+		return e
+	}
+
+	joins := record.joins()
+
+	e = out.WithMethod("joins", record.blueprint(), nil, []string{"string"}, func(scope url.Values) error {
+		if len(joins) == 0 {
+			return out.Returns(writing.EmptyString)
+		}
+
+		out.Println("%s := %s.members()", symbols.members, scope.Get("receiver"))
+		out.Println("%s := make([]string, 0, %d)", symbols.joins, len(joins))
+
+		for _, rel := range joins {
+			related := fmt.Sprintf("_related%s", rel.name)
+			reference := strconv.Quote(record.columnReference(rel.column))
+
+			out.Println("%s := make([]*%s, 0, len(%s))", related, rel.blueprint(), symbols.members)
+
+			out.WithIter("_, %s := range %s", func(url.Values) error {
+				return out.WithIf("%s.%s != nil", func(url.Values) error {
+					return out.Println("%s = append(%s, %s.%s)", related, related, symbols.member, rel.name)
+				}, symbols.member, rel.name)
+			}, symbols.member, symbols.members)
+
+			out.WithIf("len(%s) > 0", func(url.Values) error {
+				nested := fmt.Sprintf("&%s{%s: %s}", rel.blueprint(), constants.BlueprintOrGroupField, related)
+				join := fmt.Sprintf("(%s).join(%s, %s)", nested, strconv.Quote(rel.key), reference)
+				return out.Println("%s = append(%s, %s)", symbols.joins, symbols.joins, join)
+			}, related)
+		}
+
+		return out.Returns(fmt.Sprintf("strings.Join(%s, \"\")", symbols.joins))
+	})
+
+	if e != nil || len(joins) == 0 {
+		return e
+	}
+
+	members := fmt.Sprintf("[]*%s", record.blueprint())
+
+	// The 'members' method returns the blueprint along with the non-nil members of its groups, recursively.
+	return out.WithMethod("members", record.blueprint(), nil, []string{members}, func(scope url.Values) error {
+		receiver := scope.Get("receiver")
+		out.Println("%s := %s{%s}", symbols.members, members, receiver)
+
+		for _, group := range blueprintGroups {
+			out.WithIter("_, %s := range %s.%s", func(url.Values) error {
+				return out.WithIf("%s != nil", func(url.Values) error {
+					return out.Println("%s = append(%s, %s.members()...)", symbols.members, symbols.members, symbols.member)
+				}, symbols.member)
+			}, symbols.member, receiver, group.field)
+		}
+
+		return out.Returns(symbols.members)
+	})
+}
+
+// writeBlueprintOrder adds the method used by finders & selectors to build an ORDER BY clause from the OrderBy and
+// OrderDirection blueprint fields. Only the columns known to the record are accepted in order to prevent user input
+// from making its way into the generated sql.
+func writeBlueprintOrder(out writing.GoWriter, record marlowRecord) error {
+	symbols := struct {
+		columns   string
+		column    string
+		known     string
+		direction string
+	}{"_columns", "_column", "_known", "_direction"}
+
+	fields := record.fieldList(nil)
+	columns := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		name := record.fields[f.name].Get(constants.ColumnConfigOption)
+		columns = append(columns, fmt.Sprintf("\"%s\": \"%s\"", name, f.column))
+	}
+
+	returns := []string{"string", "error"}
+
+	out.Comment("[marlow] order clause for \"%s\"", record.table())
+
+	return out.WithMethod("orderByString", record.blueprint(), nil, returns, func(scope url.Values) error {
+		receiver := scope.Get("receiver")
+
+		out.WithIf("%s == nil || %s.OrderBy == \"\"", func(url.Values) error {
+			return out.Returns(writing.EmptyString, writing.Nil)
+		}, receiver, receiver)
+
+		out.Println("%s := map[string]string{%s}", symbols.columns, strings.Join(columns, ","))
+		out.Println("%s, %s := %s[%s.OrderBy]", symbols.column, symbols.known, symbols.columns, receiver)
+
+		out.WithIf("%s != true", func(url.Values) error {
+			message := fmt.Sprintf("fmt.Errorf(\"%s: %%s\", %s.OrderBy)", constants.InvalidOrderByColumnError, receiver)
+			return out.Returns(writing.EmptyString, message)
+		}, symbols.known)
+
+		out.Println("%s := strings.ToUpper(%s.OrderDirection)", symbols.direction, receiver)
+
+		out.WithIf("%s == \"\"", func(url.Values) error {
+			return out.Println("%s = \"ASC\"", symbols.direction)
+		}, symbols.direction)
+
+		out.WithIf("%s != \"ASC\" && %s != \"DESC\"", func(url.Values) error {
+			message := fmt.Sprintf(
+				"fmt.Errorf(\"%s: %%s\", %s.OrderDirection)",
+				constants.InvalidOrderDirectionError,
+				receiver,
+			)
+			return out.Returns(writing.EmptyString, message)
+		}, symbols.direction, symbols.direction)
+
+		clause := fmt.Sprintf("fmt.Sprintf(\"ORDER BY %%s %%s\", %s, %s)", symbols.column, symbols.direction)
+		return out.Returns(clause, writing.Nil)
+	})
 }
 
 func fieldMethods(record marlowRecord, name string, config url.Values, methods chan<- string) []io.Reader {
 	fieldType := config.Get("type")
 	results := make([]io.Reader, 0, len(record.fields))
-	typeInfo := getTypeInfo(fieldType)
+	typeInfo := fieldTypeInfo(config)
+
+	if jsonField(config) {
+		return jsonMethods(record, name, config, methods)
+	}
 
-	if typeInfo&types.IsConstType != 0 {
+	if arrayField(config) {
+		return arrayMethods(record, name, config, methods)
+	}
+
+	if nullableField(config) {
+		results = append(results, nullableTypeIn(record, name, config, methods))
+		results = append(results, notNullMethods(record, name, config, methods))
+	} else if comparableField(config) {
 		results = append(results, simpleTypeIn(record, name, config, methods))
 	}
 
 	if typeInfo&types.IsString != 0 {
 		results = append(results, stringMethods(record, name, config, methods))
+		results = append(results, searchMethods(record, name, config, methods))
 	}
 
 	if typeInfo&types.IsNumeric != 0 {
 		results = append(results, numericalMethods(record, name, config, methods))
 	}
 
-	if fieldType == "sql.NullInt64" {
-		results = append(results, nullableIntMethods(record, name, config, methods))
-	}
-
 	if len(results) == 0 {
 		warning := fmt.Sprintf("/* [marlow] %s (%s) unsupported type %b */\n\n", name, fieldType, typeInfo)
 		results = []io.Reader{strings.NewReader(warning)}
@@ -173,83 +496,223 @@ func fieldMethods(record marlowRecord, name string, config url.Values, methods c
 	return results
 }
 
-func nullableIntMethods(record marlowRecord, fieldName string, config url.Values, methods chan<- string) io.Reader {
+// relationMethods generates the clause method merging the clauses of the nested blueprint of a joined relation. The
+// placeholders of the nested clauses continue the numbering of the clauses that precede them.
+func relationMethods(record marlowRecord, rel relation, methods chan<- string) io.Reader {
 	pr, pw := io.Pipe()
-	columnName := config.Get(constants.ColumnConfigOption)
-	methodName := fmt.Sprintf("%sInString", columnName)
+	methodName := fmt.Sprintf("%sJoinString", rel.column)
 
 	symbols := struct {
-		placeholders string
-		values       string
-		item         string
-		result       string
-		valueCount   string
-		index        string
-	}{"_placeholders", "_values", "_v", "_joined", "_count", "_"}
+		count  string
+		clause string
+	}{"_count", "_clause"}
 
-	columnReference := fmt.Sprintf("%s.%s", record.table(), columnName)
+	returns := []string{"string", "[]interface{}"}
+	params := []writing.FuncParam{
+		{Type: "int", Symbol: symbols.count},
+	}
+
+	write := func() {
+		writer := writing.NewGoWriter(pw)
+		writer.Comment("[marlow] join clause for \"%s\" (%s)", record.columnReference(rel.column), rel.blueprint())
+
+		e := writer.WithMethod(methodName, record.blueprint(), params, returns, func(scope url.Values) error {
+			nested := fmt.Sprintf("%s.%s", scope.Get("receiver"), rel.name)
+
+			writer.WithIf("%s == nil", func(url.Values) error {
+				return writer.Returns(writing.EmptyString, writing.Nil)
+			}, nested)
+
+			writer.Println("%s := %s.clause(%s)", symbols.clause, nested, symbols.count)
+
+			writer.WithIf("%s == \"\"", func(url.Values) error {
+				return writer.Returns(writing.EmptyString, writing.Nil)
+			}, symbols.clause)
+
+			clauseString := fmt.Sprintf("fmt.Sprintf(\"(%%s)\", %s)", symbols.clause)
+			return writer.Returns(clauseString, fmt.Sprintf("%s.Values()", nested))
+		})
+
+		if e == nil {
+			methods <- methodName
+		}
+
+		pw.CloseWithError(e)
+	}
+
+	go write()
+
+	return pr
+}
+
+// blueprintGroup holds the name of a blueprint field nesting blueprints of the record & the conjunction joining them.
+type blueprintGroup struct {
+	field       string
+	conjunction string
+}
+
+// blueprintGroups are the groups of nested blueprints available on every blueprint (e.g. `(a OR b) AND c`).
+var blueprintGroups = []blueprintGroup{
+	{constants.BlueprintOrGroupField, "OR"},
+	{constants.BlueprintAndGroupField, "AND"},
+}
+
+// groupMethods generates the clause method of a group of nested blueprints. Each nested blueprint is parenthesized
+// and joined by the conjunction of the group; the placeholders of each continue the numbering of those preceding it.
+// The soft delete scope of the record is only applied once, by the outermost blueprint.
+func groupMethods(record marlowRecord, group blueprintGroup, methods chan<- string) io.Reader {
+	pr, pw := io.Pipe()
+	methodName := fmt.Sprintf("%sGroupString", strings.ToLower(group.field))
+
+	symbols := struct {
+		count   string
+		clauses string
+		values  string
+		member  string
+		clause  string
+	}{"_count", "_clauses", "_values", "_member", "_clause"}
 
 	returns := []string{"string", "[]interface{}"}
 	params := []writing.FuncParam{
-		{Type: "int", Symbol: symbols.valueCount},
+		{Type: "int", Symbol: symbols.count},
 	}
 
-	if record.dialect() == "postgres" {
-		symbols.index = "_i"
+	write := func() {
+		writer := writing.NewGoWriter(pw)
+		writer.Comment("[marlow] %s group clause for \"%s\"", group.conjunction, record.table())
+
+		e := writer.WithMethod(methodName, record.blueprint(), params, returns, func(scope url.Values) error {
+			lookup := fmt.Sprintf("%s.%s", scope.Get("receiver"), group.field)
+
+			writer.WithIf("len(%s) == 0", func(url.Values) error {
+				return writer.Returns(writing.EmptyString, writing.Nil)
+			}, lookup)
+
+			writer.Println("%s := make([]string, 0, len(%s))", symbols.clauses, lookup)
+			writer.Println("%s := make([]interface{}, 0)", symbols.values)
+
+			writer.WithIter("_, %s := range %s", func(url.Values) error {
+				writer.WithIf("%s == nil", func(url.Values) error {
+					return writer.Println("continue")
+				}, symbols.member)
+
+				writer.Println("%s := %s.where(%s+len(%s))", symbols.clause, symbols.member, symbols.count, symbols.values)
+
+				writer.WithIf("%s == \"\"", func(url.Values) error {
+					return writer.Println("continue")
+				}, symbols.clause)
+
+				clause := fmt.Sprintf("fmt.Sprintf(\"(%%s)\", %s)", symbols.clause)
+				writer.Println("%s = append(%s, %s)", symbols.clauses, symbols.clauses, clause)
+				return writer.Println("%s = append(%s, %s.Values()...)", symbols.values, symbols.values, symbols.member)
+			}, symbols.member, lookup)
+
+			writer.WithIf("len(%s) == 0", func(url.Values) error {
+				return writer.Returns(writing.EmptyString, writing.Nil)
+			}, symbols.clauses)
+
+			writer.WithIf("len(%s) == 1", func(url.Values) error {
+				return writer.Returns(fmt.Sprintf("%s[0]", symbols.clauses), symbols.values)
+			}, symbols.clauses)
+
+			joined := fmt.Sprintf("strings.Join(%s, \" %s \")", symbols.clauses, group.conjunction)
+			return writer.Returns(fmt.Sprintf("fmt.Sprintf(\"(%%s)\", %s)", joined), symbols.values)
+		})
+
+		if e == nil {
+			methods <- methodName
+		}
+
+		pw.CloseWithError(e)
+	}
+
+	go write()
+
+	return pr
+}
+
+// nullableTypeIn generates the IN clause method of nullable fields. The null values of the lookup slice (nil pointers &
+// invalid database/sql nullable values) are not sent to the database; they match the rows whose column is NULL instead.
+// For the database/sql nullable types, an empty (non-nil) lookup slice matches the rows whose column is not null.
+func nullableTypeIn(record marlowRecord, fieldName string, fieldConfig url.Values, methods chan<- string) io.Reader {
+	pr, pw := io.Pipe()
+	columnName := fieldConfig.Get(constants.ColumnConfigOption)
+	methodName := fmt.Sprintf("%sInString", columnName)
+	columnReference := record.columnReference(columnName)
+	nullCheck, value := "%s == nil", "*%s"
+	nullType, sqlNull := sqlNullField(fieldConfig)
+
+	if sqlNull {
+		nullCheck, value = "%s.Valid == false", fmt.Sprintf("%%s.%s", nullType.field)
+	}
+
+	symbols := struct {
+		placeholders    string
+		values          string
+		item            string
+		result          string
+		counter         string
+		null            string
+		placeholderItem string
+	}{"_placeholder", "_values", "_v", "_joined", "_count", "_null", "_p"}
+
+	returns := []string{"string", "[]interface{}"}
+	params := []writing.FuncParam{
+		{Type: "int", Symbol: symbols.counter},
 	}
 
 	write := func() {
 		writer := writing.NewGoWriter(pw)
-		writer.Comment("[marlow] nullable clause gen for \"%s\"", columnReference)
+		writer.Comment("[marlow] nullable type IN clause for \"%s\"", columnReference)
 
 		e := writer.WithMethod(methodName, record.blueprint(), params, returns, func(scope url.Values) error {
 			fieldReference := fmt.Sprintf("%s.%s", scope.Get("receiver"), fieldName)
+			isNull := fmt.Sprintf("%s IS NULL", columnReference)
 
-			// Add conditional check for length presence on lookup slice.
-			writer.WithIf("%s == nil", func(url.Values) error {
-				return writer.Returns(writing.EmptyString, writing.Nil)
-			}, fieldReference)
+			if sqlNull {
+				writer.WithIf("%s == nil", func(url.Values) error {
+					return writer.Returns(writing.EmptyString, writing.Nil)
+				}, fieldReference)
+			}
 
 			// Add conditional check for length presence on lookup slice.
 			writer.WithIf("len(%s) == 0", func(url.Values) error {
-				query := fmt.Sprintf("\"%s NOT NULL\"", columnReference)
-
-				if record.dialect() == "postgres" {
-					query = fmt.Sprintf("\"%s IS NOT NULL\"", columnReference)
+				if sqlNull {
+					return writer.Returns(strconv.Quote(record.dialect().NotNull(columnReference)), writing.Nil)
 				}
 
-				return writer.Returns(query, writing.Nil)
+				return writer.Returns(writing.EmptyString, writing.Nil)
 			}, fieldReference)
 
 			writer.Println("%s := make([]string, 0, len(%s))", symbols.placeholders, fieldReference)
 			writer.Println("%s := make([]interface{}, 0, len(%s))", symbols.values, fieldReference)
+			writer.Println("%s := false", symbols.null)
 
-			writer.WithIter("%s, %s := range %s", func(url.Values) error {
-				writer.WithIf("%s.Valid == false", func(url.Values) error {
-					return writer.Returns(fmt.Sprintf("\"%s IS NULL\"", columnReference), writing.Nil)
+			writer.WithIter("_, %s := range %s", func(url.Values) error {
+				writer.WithIf(nullCheck, func(url.Values) error {
+					writer.Println("%s = true", symbols.null)
+					return writer.Println("continue")
 				}, symbols.item)
 
-				// TODO: cleanup dialog placeholder generation...
-				switch record.dialect() {
-				case "postgres":
-					placeholderString := "%s = append(%s, fmt.Sprintf(\"$%%d\", %s+%s))"
-					writer.Println(
-						placeholderString,
-						symbols.placeholders,
-						symbols.placeholders,
-						symbols.index,
-						symbols.valueCount,
-					)
-				default:
-					writer.Println("%s = append(%s, \"?\")", symbols.placeholders, symbols.placeholders)
-				}
-				writer.Println("%s = append(%s, %s)", symbols.values, symbols.values, symbols.item)
-				return nil
-			}, symbols.index, symbols.item, fieldReference)
+				position := fmt.Sprintf("len(%s)+%s", symbols.values, symbols.counter)
+				writer.Println("%s := %s", symbols.placeholderItem, record.dialect().Placeholder(position))
+				writer.Println("%s = append(%s, %s)", symbols.placeholders, symbols.placeholders, symbols.placeholderItem)
+
+				return writer.Println("%s = append(%s, %s)", symbols.values, symbols.values, fmt.Sprintf(value, symbols.item))
+			}, symbols.item, fieldReference)
+
+			writer.WithIf("len(%s) == 0", func(url.Values) error {
+				return writer.Returns(strconv.Quote(isNull), writing.Nil)
+			}, symbols.values)
 
 			writer.Println("%s := strings.Join(%s, \",\")", symbols.result, symbols.placeholders)
 
-			clauseString := fmt.Sprintf("fmt.Sprintf(\"%s.%s IN (%%s)\", %s)", record.table(), columnName, symbols.result)
+			writer.WithIf("%s == true", func(url.Values) error {
+				clauseString := fmt.Sprintf("fmt.Sprintf(\"(%s IN (%%s) OR %s)\", %s)", columnReference, isNull, symbols.result)
+				return writer.Returns(clauseString, symbols.values)
+			}, symbols.null)
+
+			clauseString := fmt.Sprintf("fmt.Sprintf(\"%s IN (%%s)\", %s)", columnReference, symbols.result)
 			return writer.Returns(clauseString, symbols.values)
 		})
 
@@ -265,11 +728,49 @@ func nullableIntMethods(record marlowRecord, fieldName string, config url.Values
 	return pr
 }
 
+// notNullMethods generates the clause method of the boolean blueprint field matching the rows whose column is not null.
+func notNullMethods(record marlowRecord, fieldName string, fieldConfig url.Values, methods chan<- string) io.Reader {
+	pr, pw := io.Pipe()
+	columnName := fieldConfig.Get(constants.ColumnConfigOption)
+	methodName := fmt.Sprintf("%sNotNullString", columnName)
+	notNullSuffix := record.config.Get(constants.BlueprintNotNullFieldSuffixConfigOption)
+	notNullFieldName := fmt.Sprintf("%s%s", fieldName, notNullSuffix)
+	columnReference := record.columnReference(columnName)
+
+	returns := []string{"string", "[]interface{}"}
+	params := []writing.FuncParam{
+		{Type: "int", Symbol: "_"},
+	}
+
+	write := func() {
+		writer := writing.NewGoWriter(pw)
+		writer.Comment("[marlow] not null clause for \"%s\"", columnReference)
+
+		e := writer.WithMethod(methodName, record.blueprint(), params, returns, func(scope url.Values) error {
+			writer.WithIf("%s.%s != true", func(url.Values) error {
+				return writer.Returns(writing.EmptyString, writing.Nil)
+			}, scope.Get("receiver"), notNullFieldName)
+
+			return writer.Returns(strconv.Quote(record.dialect().NotNull(columnReference)), writing.Nil)
+		})
+
+		if e == nil {
+			methods <- methodName
+		}
+
+		pw.CloseWithError(e)
+	}
+
+	go write()
+
+	return pr
+}
+
 func simpleTypeIn(record marlowRecord, fieldName string, fieldConfig url.Values, methods chan<- string) io.Reader {
 	pr, pw := io.Pipe()
 	columnName := fieldConfig.Get(constants.ColumnConfigOption)
 	methodName := fmt.Sprintf("%sInString", columnName)
-	columnReference := fmt.Sprintf("%s.%s", record.table(), columnName)
+	columnReference := record.columnReference(columnName)
 
 	symbols := struct {
 		placeholders    string
@@ -286,7 +787,7 @@ func simpleTypeIn(record marlowRecord, fieldName string, fieldConfig url.Values,
 		{Type: "int", Symbol: symbols.counter},
 	}
 
-	if record.dialect() != "postgres" {
+	if !record.dialect().NumberedPlaceholders() {
 		symbols.index = "_"
 	}
 
@@ -306,14 +807,9 @@ func simpleTypeIn(record marlowRecord, fieldName string, fieldConfig url.Values,
 			writer.Println("%s := make([]interface{}, 0, len(%s))", symbols.values, fieldReference)
 
 			writer.WithIter("%s, %s := range %s", func(url.Values) error {
-				// TODO: cleanup dialog placeholder generation...
-				switch record.dialect() {
-				case "postgres":
-					writer.Println("%s := fmt.Sprintf(\"$%%d\", %s+%s)", symbols.placeholderItem, symbols.index, symbols.counter)
-					writer.Println("%s = append(%s, %s)", symbols.placeholders, symbols.placeholders, symbols.placeholderItem)
-				default:
-					writer.Println("%s = append(%s, \"?\")", symbols.placeholders, symbols.placeholders)
-				}
+				placeholder := record.dialect().Placeholder(fmt.Sprintf("%s+%s", symbols.index, symbols.counter))
+				writer.Println("%s := %s", symbols.placeholderItem, placeholder)
+				writer.Println("%s = append(%s, %s)", symbols.placeholders, symbols.placeholders, symbols.placeholderItem)
 
 				writer.Println("%s = append(%s, %s)", symbols.values, symbols.values, symbols.item)
 				return nil
@@ -341,7 +837,7 @@ func stringMethods(record marlowRecord, fieldName string, fieldConfig url.Values
 	methodName := fmt.Sprintf("%sLikeString", columnName)
 	likeSuffix := record.config.Get(constants.BlueprintLikeFieldSuffixConfigOption)
 	likeFieldName := fmt.Sprintf("%s%s", fieldName, likeSuffix)
-	columnReference := fmt.Sprintf("%s.%s", record.table(), columnName)
+	columnReference := record.columnReference(columnName)
 
 	symbols := struct {
 		conjunction  string
@@ -353,7 +849,7 @@ func stringMethods(record marlowRecord, fieldName string, fieldConfig url.Values
 		index        string
 	}{"_conjunc", "_placeholders", "_value", "_values", "_like", "_count", "_i"}
 
-	if record.dialect() != "postgres" {
+	if !record.dialect().NumberedPlaceholders() {
 		symbols.index = "_"
 	}
 
@@ -379,12 +875,9 @@ func stringMethods(record marlowRecord, fieldName string, fieldConfig url.Values
 			writer.Println("%s := make([]interface{}, 0, len(%s))", symbols.values, likeSlice)
 
 			writer.WithIter("%s, %s := range %s", func(url.Values) error {
-				likeString := fmt.Sprintf("\"%s LIKE ?\"", columnReference)
-
-				if record.dialect() == "postgres" {
-					psqlLike := "fmt.Sprintf(\"%s LIKE $%%d\", %s+%s)"
-					likeString = fmt.Sprintf(psqlLike, columnReference, symbols.count, symbols.index)
-				}
+				dialect := record.dialect()
+				placeholder := dialect.Placeholder(fmt.Sprintf("%s+%s", symbols.count, symbols.index))
+				likeString := sqlExpression(fmt.Sprintf("%s %s %%s", columnReference, dialect.Like()), placeholder)
 
 				writer.Println("%s := %s", symbols.statement, likeString)
 				writer.Println("%s = append(%s, %s)", symbols.placeholders, symbols.placeholders, symbols.statement)
@@ -413,20 +906,165 @@ func stringMethods(record marlowRecord, fieldName string, fieldConfig url.Values
 	return pr
 }
 
+// searchEscapeCharacter escapes the wildcards of the values of the search fields of string fields.
+const searchEscapeCharacter = "!"
+
+// blueprintSearch holds the suffix option & pattern of one of the case insensitive search fields of string fields. The
+// pattern receives the value of the field; searches with a pattern escape the wildcards (`%` & `_`) of the value.
+type blueprintSearch struct {
+	method       string
+	suffixOption string
+	pattern      string
+}
+
+// blueprintSearches are the case insensitive searches of string fields, in the order their clauses are generated.
+var blueprintSearches = []blueprintSearch{
+	{"ILike", constants.BlueprintILikeFieldSuffixConfigOption, ""},
+	{"Prefix", constants.BlueprintPrefixFieldSuffixConfigOption, "%s+\"%%\""},
+	{"Contains", constants.BlueprintContainsFieldSuffixConfigOption, "\"%%\"+%s+\"%%\""},
+	{"Suffix", constants.BlueprintSuffixFieldSuffixConfigOption, "\"%%\"+%s"},
+}
+
+// searchMethods generates the clause methods of the case insensitive searches of string fields, using the ILIKE
+// operator or comparing the lowercased column & pattern, depending on the dialect.
+func searchMethods(record marlowRecord, fieldName string, fieldConfig url.Values, methods chan<- string) io.Reader {
+	columnName := fieldConfig.Get(constants.ColumnConfigOption)
+	columnReference := record.columnReference(columnName)
+
+	symbols := struct {
+		conjunction string
+		clauses     string
+		item        string
+		values      string
+		escape      string
+		count       string
+		index       string
+	}{"_conjunc", "_clauses", "_value", "_values", "_escape", "_count", "_i"}
+
+	if !record.dialect().NumberedPlaceholders() {
+		symbols.index = "_"
+	}
+
+	returns := []string{"string", "[]interface{}"}
+	params := []writing.FuncParam{
+		{Type: "int", Symbol: symbols.count},
+	}
+
+	pr, pw := io.Pipe()
+
+	write := func(search blueprintSearch) error {
+		writer := writing.NewGoWriter(pw)
+		methodName := fmt.Sprintf("%s%sString", columnName, search.method)
+		lookupName := fmt.Sprintf("%s%s", fieldName, record.config.Get(search.suffixOption))
+		writer.Comment("[marlow] string %s clause for \"%s\"", search.method, columnReference)
+
+		e := writer.WithMethod(methodName, record.blueprint(), params, returns, func(scope url.Values) error {
+			lookup := fmt.Sprintf("%s.%s", scope.Get("receiver"), lookupName)
+
+			writer.WithIf("len(%s) == 0", func(url.Values) error {
+				return writer.Returns(writing.EmptyString, writing.Nil)
+			}, lookup)
+
+			writer.Println("%s := make([]string, 0, len(%s))", symbols.clauses, lookup)
+			writer.Println("%s := make([]interface{}, 0, len(%s))", symbols.values, lookup)
+
+			format, value := record.dialect().ILike(columnReference), symbols.item
+
+			if search.pattern != "" {
+				escape := strconv.Quote(searchEscapeCharacter)
+				writer.Println(
+					"%s := strings.NewReplacer(%s, %s, \"%%\", %s, \"_\", %s)",
+					symbols.escape,
+					escape,
+					strconv.Quote(searchEscapeCharacter+searchEscapeCharacter),
+					strconv.Quote(searchEscapeCharacter+"%"),
+					strconv.Quote(searchEscapeCharacter+"_"),
+				)
+
+				format = fmt.Sprintf("%s ESCAPE '%s'", format, searchEscapeCharacter)
+				value = fmt.Sprintf(search.pattern, fmt.Sprintf("%s.Replace(%s)", symbols.escape, symbols.item))
+			}
+
+			writer.WithIter("%s, %s := range %s", func(url.Values) error {
+				placeholder := record.dialect().Placeholder(fmt.Sprintf("%s+%s", symbols.count, symbols.index))
+				clause := sqlExpression(format, placeholder)
+
+				writer.Println("%s = append(%s, %s)", symbols.clauses, symbols.clauses, clause)
+				return writer.Println("%s = append(%s, %s)", symbols.values, symbols.values, value)
+			}, symbols.index, symbols.item, lookup)
+
+			writer.Println("%s := \" AND \"", symbols.conjunction)
+
+			writer.WithIf("%s.Inclusive == true", func(url.Values) error {
+				return writer.Println("%s = \" OR \"", symbols.conjunction)
+			}, scope.Get("receiver"))
+
+			clauseString := fmt.Sprintf("strings.Join(%s, %s)", symbols.clauses, symbols.conjunction)
+			return writer.Returns(clauseString, symbols.values)
+		})
+
+		if e == nil {
+			methods <- methodName
+		}
+
+		return e
+	}
+
+	go func() {
+		for _, search := range blueprintSearches {
+			if e := write(search); e != nil {
+				pw.CloseWithError(e)
+				return
+			}
+		}
+
+		pw.Close()
+	}()
+
+	return pr
+}
+
+// blueprintBound holds the suffix option & comparison operator of one of the bound fields of numerical fields.
+type blueprintBound struct {
+	suffixOption string
+	operator     string
+}
+
+// blueprintBounds are the bounds of numerical fields, in the order their clauses are generated.
+var blueprintBounds = []blueprintBound{
+	{constants.BlueprintGtFieldSuffixConfigOption, ">"},
+	{constants.BlueprintGteFieldSuffixConfigOption, ">="},
+	{constants.BlueprintLtFieldSuffixConfigOption, "<"},
+	{constants.BlueprintLteFieldSuffixConfigOption, "<="},
+}
+
+// numericalMethods generates the range, bounds & NOT IN clause methods of numerical fields. The bounds of a field are
+// joined by AND and grouped like the range clause; an inclusive blueprint does not OR a lower bound with an upper one.
 func numericalMethods(record marlowRecord, fieldName string, fieldConfig url.Values, methods chan<- string) io.Reader {
 	columnName := fieldConfig.Get(constants.ColumnConfigOption)
 	rangeMethodName := fmt.Sprintf("%sRangeString", columnName)
 	rangeFieldName := fmt.Sprintf("%s%s", fieldName, record.config.Get(constants.BlueprintRangeFieldSuffixConfigOption))
-	columnReference := fmt.Sprintf("%s.%s", record.table(), columnName)
+	boundsMethodName := fmt.Sprintf("%sBoundsString", columnName)
+	notInMethodName := fmt.Sprintf("%sNotInString", columnName)
+	notFieldName := fmt.Sprintf("%s%s", fieldName, record.config.Get(constants.BlueprintNotFieldSuffixConfigOption))
+	columnReference := record.columnReference(columnName)
 
 	pr, pw := io.Pipe()
 
 	returns := []string{"string", "[]interface{}"}
 
 	symbols := struct {
-		values string
-		count  string
-	}{"_values", "_count"}
+		values       string
+		count        string
+		clauses      string
+		placeholders string
+		item         string
+		index        string
+	}{"_values", "_count", "_clauses", "_placeholders", "_v", "_i"}
+
+	if !record.dialect().NumberedPlaceholders() {
+		symbols.index = "_"
+	}
 
 	params := []writing.FuncParam{
 		{Type: "int", Symbol: symbols.count},
@@ -449,23 +1087,80 @@ func numericalMethods(record marlowRecord, fieldName string, fieldConfig url.Val
 			writer.Println("%s[0] = %s[0]", symbols.values, rangeArray)
 			writer.Println("%s[1] = %s[1]", symbols.values, rangeArray)
 
-			if record.dialect() == "postgres" {
-				rangeString := fmt.Sprintf(
-					"fmt.Sprintf(\"(%s > $%%d AND %s < $%%d)\", %s, %s+1)",
-					columnReference,
-					columnReference,
-					symbols.count,
-					symbols.count,
-				)
+			rangeString := sqlExpression(
+				fmt.Sprintf("(%s > %%s AND %s < %%s)", columnReference, columnReference),
+				record.dialect().Placeholder(symbols.count),
+				record.dialect().Placeholder(fmt.Sprintf("%s+1", symbols.count)),
+			)
+
+			return writer.Returns(rangeString, symbols.values)
+		})
+
+		if e != nil {
+			pw.CloseWithError(e)
+			return
+		}
+
+		methods <- rangeMethodName
+
+		e = writer.WithMethod(boundsMethodName, record.blueprint(), params, returns, func(scope url.Values) error {
+			receiver := scope.Get("receiver")
+
+			writer.Println("%s := make([]string, 0, %d)", symbols.clauses, len(blueprintBounds))
+			writer.Println("%s := make([]interface{}, 0, %d)", symbols.values, len(blueprintBounds))
+
+			for _, bound := range blueprintBounds {
+				lookup := fmt.Sprintf("%s.%s%s", receiver, fieldName, record.config.Get(bound.suffixOption))
 
-				return writer.Returns(rangeString, symbols.values)
+				writer.WithIf("%s != nil", func(url.Values) error {
+					clause := sqlExpression(
+						fmt.Sprintf("%s %s %%s", columnReference, bound.operator),
+						record.dialect().Placeholder(fmt.Sprintf("%s+len(%s)", symbols.count, symbols.values)),
+					)
+
+					writer.Println("%s = append(%s, %s)", symbols.clauses, symbols.clauses, clause)
+					return writer.Println("%s = append(%s, *%s)", symbols.values, symbols.values, lookup)
+				}, lookup)
 			}
 
-			return writer.Returns(fmt.Sprintf("\"(%s > ? AND %s < ?)\"", columnReference, columnReference), symbols.values)
+			writer.WithIf("len(%s) == 0", func(url.Values) error {
+				return writer.Returns(writing.EmptyString, writing.Nil)
+			}, symbols.clauses)
+
+			clauseString := fmt.Sprintf("fmt.Sprintf(\"(%%s)\", strings.Join(%s, \" AND \"))", symbols.clauses)
+			return writer.Returns(clauseString, symbols.values)
+		})
+
+		if e != nil {
+			pw.CloseWithError(e)
+			return
+		}
+
+		methods <- boundsMethodName
+
+		e = writer.WithMethod(notInMethodName, record.blueprint(), params, returns, func(scope url.Values) error {
+			lookup := fmt.Sprintf("%s.%s", scope.Get("receiver"), notFieldName)
+
+			writer.WithIf("len(%s) == 0", func(url.Values) error {
+				return writer.Returns(writing.EmptyString, writing.Nil)
+			}, lookup)
+
+			writer.Println("%s := make([]string, 0, len(%s))", symbols.placeholders, lookup)
+			writer.Println("%s := make([]interface{}, 0, len(%s))", symbols.values, lookup)
+
+			writer.WithIter("%s, %s := range %s", func(url.Values) error {
+				placeholder := record.dialect().Placeholder(fmt.Sprintf("%s+%s", symbols.index, symbols.count))
+				writer.Println("%s = append(%s, %s)", symbols.placeholders, symbols.placeholders, placeholder)
+				return writer.Println("%s = append(%s, %s)", symbols.values, symbols.values, symbols.item)
+			}, symbols.index, symbols.item, lookup)
+
+			joined := fmt.Sprintf("strings.Join(%s, \",\")", symbols.placeholders)
+			clauseString := fmt.Sprintf("fmt.Sprintf(\"%s NOT IN (%%s)\", %s)", columnReference, joined)
+			return writer.Returns(clauseString, symbols.values)
 		})
 
 		if e == nil {
-			methods <- rangeMethodName
+			methods <- notInMethodName
 		}
 
 		pw.CloseWithError(e)
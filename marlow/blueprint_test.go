@@ -4,6 +4,7 @@ import "io"
 import "fmt"
 import "sync"
 import "bytes"
+import "strings"
 import "testing"
 import "net/url"
 import "go/token"
@@ -124,6 +125,34 @@ func Test_Blueprint(t *testing.T) {
 				g.Assert(e).Equal(nil)
 			})
 
+			g.It("adds the groups of nested blueprints, merging their clauses & values", func() {
+				_, e := io.Copy(b, newBlueprintGenerator(record))
+				g.Assert(e).Equal(nil)
+				g.Assert(strings.Contains(b.String(), "Or []*SomeBlueprint")).Equal(true)
+				g.Assert(strings.Contains(b.String(), "And []*SomeBlueprint")).Equal(true)
+				g.Assert(strings.Contains(b.String(), "_clause := _member.where(_count+len(_values))")).Equal(true)
+				g.Assert(strings.Contains(b.String(), "strings.Join(_clauses, \" OR \")")).Equal(true)
+				g.Assert(strings.Contains(b.String(), "s.andGroupString(_count)")).Equal(true)
+			})
+
+			g.It("returns an error if a group conflicts with a field", func() {
+				f["Or"] = url.Values{
+					"type":   []string{"string"},
+					"column": []string{"or_value"},
+				}
+
+				_, e := io.Copy(b, newBlueprintGenerator(record))
+				g.Assert(e == nil).Equal(false)
+			})
+
+			g.It("whitelists the record's columns for the order clause", func() {
+				r.Set(constants.TableNameConfigOption, "books")
+				_, e := io.Copy(b, newBlueprintGenerator(record))
+				g.Assert(e).Equal(nil)
+				g.Assert(strings.Contains(b.String(), "orderByString()")).Equal(true)
+				g.Assert(strings.Contains(b.String(), "\"page_count\": \"books.page_count\"")).Equal(true)
+			})
+
 			g.Describe("with a postgres record dialect", func() {
 				g.BeforeEach(func() {
 					r.Set(constants.DialectConfigOption, "postgres")
@@ -136,6 +165,319 @@ func Test_Blueprint(t *testing.T) {
 					_, e = parser.ParseFile(token.NewFileSet(), "", b, parser.AllErrors)
 					g.Assert(e).Equal(nil)
 				})
+
+				g.It("numbers the placeholders of the bounds by the values preceding them", func() {
+					r.Set(constants.TableNameConfigOption, "books")
+					r.Set(constants.BlueprintGteFieldSuffixConfigOption, "Gte")
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "PageCountGte *int")).Equal(true)
+					clause := "fmt.Sprintf(\"books.page_count >= %s\", fmt.Sprintf(\"$%d\", _count+len(_values)))"
+					g.Assert(strings.Contains(b.String(), clause)).Equal(true)
+				})
+			})
+
+			g.Describe("with a mysql record dialect", func() {
+				g.BeforeEach(func() {
+					r.Set(constants.DialectConfigOption, "mysql")
+					r.Set(constants.TableNameConfigOption, "books")
+				})
+
+				g.It("produced valid a golang struct", func() {
+					fmt.Fprintln(b, "package marlowt")
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					_, e = parser.ParseFile(token.NewFileSet(), "", b, parser.AllErrors)
+					g.Assert(e).Equal(nil)
+				})
+
+				g.It("quotes the table and column identifiers with backticks", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "`books`.`page_count` IN (%s)")).Equal(true)
+				})
+
+				g.It("uses a case sensitive like comparison", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "LIKE BINARY ?")).Equal(true)
+				})
+
+				g.It("lowercases the column & pattern of the case insensitive searches", func() {
+					r.Set(constants.BlueprintContainsFieldSuffixConfigOption, "Contains")
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "NameContains []string")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "\"LOWER(`books`.`name`) LIKE LOWER(?) ESCAPE '!'\"")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "\"%\"+_escape.Replace(_value)+\"%\"")).Equal(true)
+				})
+			})
+
+			g.Describe("with a soft delete column", func() {
+				g.BeforeEach(func() {
+					r.Set(constants.SoftDeleteConfigOption, "deleted_at")
+					r.Set(constants.TableNameConfigOption, "books")
+				})
+
+				g.It("produced valid a golang struct", func() {
+					fmt.Fprintln(b, "package marlowt")
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					_, e = parser.ParseFile(token.NewFileSet(), "", b, parser.AllErrors)
+					g.Assert(e).Equal(nil)
+				})
+
+				g.It("adds the WithDeleted and OnlyDeleted fields to the blueprint", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "WithDeleted bool")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "OnlyDeleted bool")).Equal(true)
+				})
+
+				g.It("excludes the soft deleted records by default", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "\"books.deleted_at IS NULL\"")).Equal(true)
+				})
+			})
+
+			g.Describe("with pointer fields", func() {
+				g.BeforeEach(func() {
+					r.Set(constants.TableNameConfigOption, "books")
+					r.Set(constants.BlueprintRangeFieldSuffixConfigOption, "Range")
+					r.Set(constants.BlueprintNotNullFieldSuffixConfigOption, "NotNull")
+
+					f["Subtitle"] = url.Values{
+						"type":   []string{"*string"},
+						"column": []string{"subtitle"},
+					}
+
+					f["Rating"] = url.Values{
+						"type":       []string{"*Rating"},
+						"underlying": []string{"float64"},
+						"column":     []string{"rating"},
+					}
+				})
+
+				g.It("produced valid a golang struct", func() {
+					fmt.Fprintln(b, "package marlowt")
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					_, e = parser.ParseFile(token.NewFileSet(), "", b, parser.AllErrors)
+					g.Assert(e).Equal(nil)
+				})
+
+				g.It("adds the not null toggles & uses the type pointed to for range fields", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "SubtitleNotNull")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "RatingRange []Rating")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "\"books.rating NOT NULL\"")).Equal(true)
+				})
+
+				g.It("matches null columns for the nil values of the lookup", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "_values = append(_values, *_v)")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "(books.subtitle IN (%s) OR books.subtitle IS NULL)")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "\"books.subtitle IS NULL\"")).Equal(true)
+				})
+
+				g.It("numbers the placeholders of non-nil values for postgres", func() {
+					r.Set(constants.DialectConfigOption, "postgres")
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "fmt.Sprintf(\"$%d\", len(_values)+_count)")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "\"books.subtitle IS NOT NULL\"")).Equal(true)
+				})
+			})
+
+			g.Describe("with database/sql nullable fields", func() {
+				g.BeforeEach(func() {
+					r.Set(constants.TableNameConfigOption, "books")
+					r.Set(constants.BlueprintLikeFieldSuffixConfigOption, "Like")
+					r.Set(constants.BlueprintRangeFieldSuffixConfigOption, "Range")
+					r.Set(constants.BlueprintNotNullFieldSuffixConfigOption, "NotNull")
+
+					f["Subtitle"] = url.Values{
+						"type":   []string{"sql.NullString"},
+						"column": []string{"subtitle"},
+					}
+
+					f["PublishedAt"] = url.Values{
+						"type":   []string{"sql.NullTime"},
+						"column": []string{"published_at"},
+					}
+				})
+
+				g.It("produced valid a golang struct", func() {
+					fmt.Fprintln(b, "package marlowt")
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					_, e = parser.ParseFile(token.NewFileSet(), "", b, parser.AllErrors)
+					g.Assert(e).Equal(nil)
+				})
+
+				g.It("adds the like, range & not null fields using the type of the value", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "SubtitleLike")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "SubtitleNotNull")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "PublishedAtRange []time.Time")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "PublishedAtNotNull")).Equal(true)
+				})
+
+				g.It("sends the values of the valid items & matches null columns for the invalid items", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "if _v.Valid == false {")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "_values = append(_values, _v.String)")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "_values = append(_values, _v.Time)")).Equal(true)
+				})
+
+				g.It("injected the time library to the import stream", func() {
+					io.Copy(b, newBlueprintGenerator(record))
+					closed = true
+					close(imports)
+					wg.Wait()
+					g.Assert(receivedImports["time"]).Equal(true)
+				})
+			})
+
+			g.Describe("with a field referencing another record", func() {
+				g.BeforeEach(func() {
+					r.Set(constants.TableNameConfigOption, "books")
+
+					f["AuthorID"] = url.Values{
+						"type":                           []string{"int"},
+						"column":                         []string{"author"},
+						constants.ColumnReferencesOption: []string{"Author.ID"},
+					}
+				})
+
+				g.It("produced valid a golang struct", func() {
+					fmt.Fprintln(b, "package marlowt")
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					_, e = parser.ParseFile(token.NewFileSet(), "", b, parser.AllErrors)
+					g.Assert(e).Equal(nil)
+				})
+
+				g.It("adds the nested blueprint of the referenced record", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "Author *AuthorBlueprint")).Equal(true)
+				})
+
+				g.It("merges the nested clauses, continuing the placeholder count", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "where(_count int) string")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "_clause := s.Author.clause(_count)")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "s.authorJoinString(_count)")).Equal(true)
+				})
+
+				g.It("joins the table of the referenced record using the referenced field", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					join := "(&AuthorBlueprint{Or: _relatedAuthor}).join(\"ID\", \"books.author\")"
+					g.Assert(strings.Contains(b.String(), join)).Equal(true)
+					g.Assert(strings.Contains(b.String(), "\"AuthorID\": \"books.author\"")).Equal(true)
+				})
+
+				g.It("joins the tables of the relation blueprints nested in the members of groups", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "_members := s.members()")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "_relatedAuthor = append(_relatedAuthor, _member.Author)")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "func (s *SomeBlueprint) members() []*SomeBlueprint")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "for _, _member := range s.Or {")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "_members = append(_members, _member.members()...)")).Equal(true)
+				})
+
+				g.It("does not add a nested blueprint for references to the record itself", func() {
+					f["AuthorID"].Set(constants.ColumnReferencesOption, "Book.ID")
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "*BookBlueprint")).Equal(false)
+				})
+
+				g.It("returns an error if the relation name conflicts with a field", func() {
+					f["Author"] = url.Values{
+						"type":   []string{"string"},
+						"column": []string{"author_name"},
+					}
+
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e == nil).Equal(false)
+				})
+
+				g.Describe("with a postgres record dialect", func() {
+					g.BeforeEach(func() {
+						r.Set(constants.DialectConfigOption, "postgres")
+					})
+
+					g.It("produced valid a golang struct", func() {
+						fmt.Fprintln(b, "package marlowt")
+						_, e := io.Copy(b, newBlueprintGenerator(record))
+						g.Assert(e).Equal(nil)
+						_, e = parser.ParseFile(token.NewFileSet(), "", b, parser.AllErrors)
+						g.Assert(e).Equal(nil)
+					})
+
+					g.It("numbers the placeholders from the provided count", func() {
+						_, e := io.Copy(b, newBlueprintGenerator(record))
+						g.Assert(e).Equal(nil)
+						g.Assert(strings.Contains(b.String(), "fmt.Sprintf(\"$%d\", _i+_count)")).Equal(true)
+						g.Assert(strings.Contains(b.String(), "_where := s.clause(1)")).Equal(true)
+					})
+				})
+			})
+
+			g.Describe("with an array field of a postgres record", func() {
+				g.BeforeEach(func() {
+					r.Set(constants.DialectConfigOption, "postgres")
+					r.Set(constants.TableNameConfigOption, "books")
+					r.Set(constants.BlueprintArrayContainsFieldSuffixConfigOption, "Contains")
+					r.Set(constants.BlueprintArrayOverlapsFieldSuffixConfigOption, "Overlaps")
+
+					f["Tags"] = url.Values{
+						"type":   []string{"[]string"},
+						"column": []string{"tags"},
+					}
+				})
+
+				g.It("produced valid a golang struct", func() {
+					fmt.Fprintln(b, "package marlowt")
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					_, e = parser.ParseFile(token.NewFileSet(), "", b, parser.AllErrors)
+					g.Assert(e).Equal(nil)
+				})
+
+				g.It("adds the contains & overlaps fields instead of the in lookup of the field", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(b.String(), "TagsContains []string")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "TagsOverlaps []string")).Equal(true)
+					g.Assert(strings.Contains(b.String(), "Tags [][]string")).Equal(false)
+				})
+
+				g.It("sends the elements of the lookups as a single array value", func() {
+					_, e := io.Copy(b, newBlueprintGenerator(record))
+					g.Assert(e).Equal(nil)
+					clause := "fmt.Sprintf(\"books.tags && %s\", fmt.Sprintf(\"$%d\", _count))"
+					g.Assert(strings.Contains(b.String(), clause)).Equal(true)
+					g.Assert(strings.Contains(b.String(), "[]interface{}{pq.Array(s.TagsContains)}")).Equal(true)
+				})
+
+				g.It("injected the pq library to the import stream", func() {
+					io.Copy(b, newBlueprintGenerator(record))
+					closed = true
+					close(imports)
+					wg.Wait()
+					g.Assert(receivedImports["github.com/lib/pq"]).Equal(true)
+				})
 			})
 		})
 
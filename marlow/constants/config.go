@@ -4,6 +4,29 @@ const (
 	// StoreLoggerField is the internal field on stores for the io.Writer log stream
 	StoreLoggerField = "logger"
 
+	// StoreDatabaseField is the internal field on stores holding the *sql.DB the store was created with.
+	StoreDatabaseField = "db"
+
+	// StoreTransactionField is the internal field on stores holding the *sql.Tx the store is bound to, if any.
+	StoreTransactionField = "tx"
+
+	// StoreClockField is the internal field on stores holding the function used to read the current time.
+	StoreClockField = "clock"
+
+	// SharedStoresName is the name of the struct generated once per package, grouping the stores of the records that
+	// opt in using the SharedStoresConfigOption.
+	SharedStoresName = "Stores"
+
+	// SharedStaleErrorName is the name of the error generated once per package, wrapped by the stale error of every
+	// versioned record.
+	SharedStaleErrorName = "ErrStaleRecord"
+
+	// BlueprintOrGroupField is the blueprint field holding the nested blueprints whose clauses are joined by OR.
+	BlueprintOrGroupField = "Or"
+
+	// BlueprintAndGroupField is the blueprint field holding the nested blueprints whose clauses are joined by AND.
+	BlueprintAndGroupField = "And"
+
 	// PrimaryKeyColumnConfigOption specifies the primary key on the record
 	PrimaryKeyColumnConfigOption = "primaryKey"
 
@@ -26,10 +49,66 @@ const (
 	// searching ranges on numerical field types.
 	BlueprintRangeFieldSuffixConfigOption = "blueprintRangeFieldSuffix"
 
+	// BlueprintGtFieldSuffixConfigOption is the string that will be appended to the blueprint fields of numerical fields
+	// used to match rows whose column is greater than a value.
+	BlueprintGtFieldSuffixConfigOption = "blueprintGtFieldSuffix"
+
+	// BlueprintGteFieldSuffixConfigOption is the string that will be appended to the blueprint fields of numerical fields
+	// used to match rows whose column is greater than or equal to a value.
+	BlueprintGteFieldSuffixConfigOption = "blueprintGteFieldSuffix"
+
+	// BlueprintLtFieldSuffixConfigOption is the string that will be appended to the blueprint fields of numerical fields
+	// used to match rows whose column is less than a value.
+	BlueprintLtFieldSuffixConfigOption = "blueprintLtFieldSuffix"
+
+	// BlueprintLteFieldSuffixConfigOption is the string that will be appended to the blueprint fields of numerical fields
+	// used to match rows whose column is less than or equal to a value.
+	BlueprintLteFieldSuffixConfigOption = "blueprintLteFieldSuffix"
+
+	// BlueprintNotFieldSuffixConfigOption is the string that will be appended to the blueprint fields of numerical fields
+	// used to exclude the rows whose column is one of the provided values (NOT IN).
+	BlueprintNotFieldSuffixConfigOption = "blueprintNotFieldSuffix"
+
 	// BlueprintLikeFieldSuffixConfigOption is the string that will be appened to string/text fields and used for LIKE
 	// searching by the queryable interface.
 	BlueprintLikeFieldSuffixConfigOption = "blueprintLikeFieldSuffix"
 
+	// BlueprintILikeFieldSuffixConfigOption is the string that will be appended to string/text fields and used for case
+	// insensitive LIKE searching by the queryable interface.
+	BlueprintILikeFieldSuffixConfigOption = "blueprintILikeFieldSuffix"
+
+	// BlueprintPrefixFieldSuffixConfigOption is the string that will be appended to the blueprint fields of string/text
+	// fields used to match rows whose column starts with a value, ignoring case.
+	BlueprintPrefixFieldSuffixConfigOption = "blueprintPrefixFieldSuffix"
+
+	// BlueprintContainsFieldSuffixConfigOption is the string that will be appended to the blueprint fields of string/text
+	// fields used to match rows whose column contains a value, ignoring case.
+	BlueprintContainsFieldSuffixConfigOption = "blueprintContainsFieldSuffix"
+
+	// BlueprintSuffixFieldSuffixConfigOption is the string that will be appended to the blueprint fields of string/text
+	// fields used to match rows whose column ends with a value, ignoring case.
+	BlueprintSuffixFieldSuffixConfigOption = "blueprintSuffixFieldSuffix"
+
+	// BlueprintNotNullFieldSuffixConfigOption is the string that will be appended to the boolean blueprint fields of
+	// pointer fields used to match rows whose column is not null.
+	BlueprintNotNullFieldSuffixConfigOption = "blueprintNotNullFieldSuffix"
+
+	// BlueprintJSONContainsFieldSuffixConfigOption is the string that will be appended to the blueprint fields of json
+	// fields used to match rows whose json column contains a document (e.g. the postgres `@>` operator).
+	BlueprintJSONContainsFieldSuffixConfigOption = "blueprintJSONContainsFieldSuffix"
+
+	// BlueprintJSONKeyFieldSuffixConfigOption is the string that will be appended to the blueprint fields of json fields
+	// used to match rows whose json column holds a value at a key (e.g. the postgres `->>` operator).
+	BlueprintJSONKeyFieldSuffixConfigOption = "blueprintJSONKeyFieldSuffix"
+
+	// BlueprintArrayContainsFieldSuffixConfigOption is the string that will be appended to the blueprint fields of array
+	// fields used to match rows whose array column contains each of the provided elements (the postgres `@>` operator).
+	BlueprintArrayContainsFieldSuffixConfigOption = "blueprintArrayContainsFieldSuffix"
+
+	// BlueprintArrayOverlapsFieldSuffixConfigOption is the string that will be appended to the blueprint fields of array
+	// fields used to match rows whose array column has any of the provided elements (the postgres `&&` operator).
+	BlueprintArrayOverlapsFieldSuffixConfigOption = "blueprintArrayOverlapsFieldSuffix"
+
 	// BlueprintNameSuffix is added after the record name for the type that can be stringifyed into valid sql code.
 	BlueprintNameSuffix = "Blueprint"
 
@@ -57,6 +136,43 @@ const (
 	// ColumnBitmaskOption is used to indicate a field is a bitmask & can be used to generate bitwise ops.
 	ColumnBitmaskOption = "bitmask"
 
+	// ColumnCreatedAtFlag indicates a time.Time field is set to the current time of the store when records are created.
+	ColumnCreatedAtFlag = "createdAt"
+
+	// ColumnUpdatedAtFlag indicates a time.Time field is set to the current time of the store whenever the record is
+	// created or updated.
+	ColumnUpdatedAtFlag = "updatedAt"
+
+	// ColumnVersionFlag indicates an integer field holds the version of the record used for optimistic locking. The
+	// generated update methods only affect rows at the expected version & increment the column.
+	ColumnVersionFlag = "version"
+
+	// ColumnReferencesOption names the record & field referenced by a field (e.g. `references=Author.ID`), used to
+	// generate the belongs-to & has-many lookups between the records.
+	ColumnReferencesOption = "references"
+
+	// ColumnKindOption declares the comparisons supported by a field whose type marlow cannot classify on its own (e.g.
+	// custom sql.Scanner & driver.Valuer implementations), determining the blueprint clauses generated for the field.
+	ColumnKindOption = "kind"
+
+	// StringColumnKind fields are matched using IN & LIKE clauses.
+	StringColumnKind = "string"
+
+	// NumericColumnKind fields are matched using IN & range clauses.
+	NumericColumnKind = "numeric"
+
+	// OpaqueColumnKind fields are only matched using IN clauses.
+	OpaqueColumnKind = "opaque"
+
+	// ColumnJSONFlag indicates a struct, map or json.RawMessage field is stored as a json document; the field is marshaled
+	// when sent to the database & unmarshaled when scanned.
+	ColumnJSONFlag = "json"
+
+	// ColumnEnumOption restricts a field to a fixed set of values. Listed values (e.g. `enum=pending,active`, or
+	// `enum=low:1,high:2` for integer types) generate typed constants for the field's type; without values the constants
+	// of the type declared in the package are used.
+	ColumnEnumOption = "enum"
+
 	// QueryableConfigOption boolean value, true/false based on fields ability to be updated.
 	QueryableConfigOption = "queryable"
 
@@ -68,4 +184,20 @@ const (
 
 	// CreateableConfigOption boolean record config option for generating the creation api methods.
 	CreateableConfigOption = "createable"
+
+	// SoftDeleteConfigOption is the record config option naming the timestamp column used to flag deleted records. When
+	// present, deletions update the column instead of removing rows and lookups exclude the flagged records by default.
+	SoftDeleteConfigOption = "softDelete"
+
+	// UpsertableConfigOption boolean record config option for generating the upsert (insert-or-update) api methods.
+	// Unlike the other features, upserts are only generated when explicitly enabled.
+	UpsertableConfigOption = "upsertable"
+
+	// ContextMethodsConfigOption boolean record config option for generating the context.Context aware variants of the
+	// store methods (e.g. "FindBooksContext").
+	ContextMethodsConfigOption = "contextMethods"
+
+	// SharedStoresConfigOption boolean record config option for grouping the store of the record in the struct shared by
+	// the records of the package. Like upserts, the struct is only generated when explicitly enabled by a record.
+	SharedStoresConfigOption = "sharedStores"
 )
@@ -4,6 +4,34 @@ const (
 	// InvalidDeletionBlueprint returned from the delete api when the blueprint generates no where clause.
 	InvalidDeletionBlueprint = "deletion blueprints must generate limiting clauses"
 
+	// InvalidVersionedUpdateBlueprint returned from the update api of versioned records when the blueprint generates no
+	// where clause.
+	InvalidVersionedUpdateBlueprint = "versioned update blueprints must generate limiting clauses"
+
+	// InvalidJoinBlueprint returned from the update & delete apis when the blueprint filters by a related record.
+	InvalidJoinBlueprint = "update & deletion blueprints must not filter by related records"
+
+	// InvalidCreateRecordError is returned from the key returning creation api when one of the records provided is nil.
+	InvalidCreateRecordError = "created records must not be nil"
+
+	// InvalidUpdateRecordError is returned from the whole-record update api when the record provided is nil.
+	InvalidUpdateRecordError = "update records must not be nil"
+
+	// InvalidEnumValueError is returned from the creation, update & upsert apis when an enum field holds an unknown value.
+	InvalidEnumValueError = "invalid enum value"
+
+	// InvalidUpsertConflictError is returned from the upsert api when no conflict columns were provided.
+	InvalidUpsertConflictError = "upserts require at least one conflict column"
+
+	// InvalidUpsertConflictColumnError is returned from the upsert api when a conflict column is not a known column.
+	InvalidUpsertConflictColumnError = "invalid upsert conflict column"
+
+	// InvalidOrderByColumnError is returned from finders & selectors when the blueprint's OrderBy is not a known column.
+	InvalidOrderByColumnError = "invalid blueprint order column"
+
+	// InvalidOrderDirectionError is returned from finders & selectors when the blueprint's OrderDirection is not valid.
+	InvalidOrderDirectionError = "invalid blueprint order direction"
+
 	// InvalidGeneratedCodeError is the message that is returned when marlow generates invalid code. Typically a problem
 	// with marlow, not necessarily the source data.
 	InvalidGeneratedCodeError = "Marlow was unable to generate valid golang code. " +
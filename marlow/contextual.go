@@ -0,0 +1,78 @@
+package marlow
+
+import "fmt"
+import "strings"
+import "net/url"
+import "github.com/dadleyy/marlow/marlow/writing"
+
+const (
+	// contextSymbol is the parameter name used for the context.Context on context-aware store methods.
+	contextSymbol = "_ctx"
+
+	// backgroundContext is the expression used by store methods that were not given a context.
+	backgroundContext = "context.Background()"
+)
+
+// contextBlock is used to write the body of a store method. The ctx value is the golang expression that should be sent
+// as the context.Context to the database/sql "Context" methods (PrepareContext, QueryContext, ExecContext).
+type contextBlock func(ctx string, scope url.Values) error
+
+// writeContextualMethod writes a store method and registers it on the record's store. If the record has context
+// methods enabled, the body is written into a "<name>Context" variant that accepts a context.Context as its first
+// parameter and the plain method simply delegates to it using context.Background().
+func writeContextualMethod(
+	gosrc writing.GoWriter,
+	record marlowRecord,
+	name string,
+	params []writing.FuncParam,
+	returns []string,
+	block contextBlock,
+) error {
+	record.registerImports("context")
+
+	if record.contextual() != true {
+		e := gosrc.WithMethod(name, record.store(), params, returns, func(scope url.Values) error {
+			return block(backgroundContext, scope)
+		})
+
+		if e == nil {
+			record.registerStoreMethod(writing.FuncDecl{Name: name, Params: params, Returns: returns})
+		}
+
+		return e
+	}
+
+	contextName := fmt.Sprintf("%sContext", name)
+	contextParams := append([]writing.FuncParam{{Type: "context.Context", Symbol: contextSymbol}}, params...)
+
+	e := gosrc.WithMethod(contextName, record.store(), contextParams, returns, func(scope url.Values) error {
+		return block(contextSymbol, scope)
+	})
+
+	if e != nil {
+		return e
+	}
+
+	arguments := []string{backgroundContext}
+
+	for _, p := range params {
+		if strings.HasPrefix(p.Type, "...") {
+			arguments = append(arguments, fmt.Sprintf("%s...", p.Symbol))
+			continue
+		}
+
+		arguments = append(arguments, p.Symbol)
+	}
+
+	e = gosrc.WithMethod(name, record.store(), params, returns, func(scope url.Values) error {
+		return gosrc.Returns(fmt.Sprintf("%s.%s(%s)", scope.Get("receiver"), contextName, strings.Join(arguments, ",")))
+	})
+
+	if e != nil {
+		return e
+	}
+
+	record.registerStoreMethod(writing.FuncDecl{Name: contextName, Params: contextParams, Returns: returns})
+	record.registerStoreMethod(writing.FuncDecl{Name: name, Params: params, Returns: returns})
+	return nil
+}
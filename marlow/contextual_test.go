@@ -0,0 +1,78 @@
+package marlow
+
+import "fmt"
+import "bytes"
+import "strings"
+import "testing"
+import "net/url"
+import "go/token"
+import "go/parser"
+import "github.com/franela/goblin"
+import "github.com/dadleyy/marlow/marlow/writing"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+func Test_ContextualMethod(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("writeContextualMethod", func() {
+		var record marlowRecord
+		var output *bytes.Buffer
+		var methods chan writing.FuncDecl
+		var imports chan string
+
+		params := []writing.FuncParam{{Type: "...Book", Symbol: "_records"}}
+		returns := []string{"int64", "error"}
+
+		block := func(ctx string, scope url.Values) error {
+			return writing.NewGoWriter(output).Returns(fmt.Sprintf("int64(len(%s.Err().Error()))", ctx), writing.Nil)
+		}
+
+		g.BeforeEach(func() {
+			output = new(bytes.Buffer)
+			methods = make(chan writing.FuncDecl, 10)
+			imports = make(chan string, 10)
+			record = marlowRecord{
+				config:        make(url.Values),
+				importChannel: imports,
+				storeChannel:  methods,
+			}
+			record.config.Set(constants.StoreNameConfigOption, "BookStore")
+			fmt.Fprintln(output, "package marlowt")
+		})
+
+		g.It("writes both the plain and context-aware methods by default", func() {
+			e := writeContextualMethod(writing.NewGoWriter(output), record, "CreateBooks", params, returns, block)
+			g.Assert(e).Equal(nil)
+			close(methods)
+
+			names := make([]string, 0, 2)
+
+			for m := range methods {
+				names = append(names, m.Name)
+			}
+
+			g.Assert(names).Equal([]string{"CreateBooksContext", "CreateBooks"})
+			g.Assert(strings.Contains(output.String(), "CreateBooksContext(context.Background(),_records...)")).Equal(true)
+			_, e = parser.ParseFile(token.NewFileSet(), "", output, parser.AllErrors)
+			g.Assert(e).Equal(nil)
+		})
+
+		g.It("writes only the plain method using the background context if disabled on the record", func() {
+			record.config.Set(constants.ContextMethodsConfigOption, "false")
+			e := writeContextualMethod(writing.NewGoWriter(output), record, "CreateBooks", params, returns, block)
+			g.Assert(e).Equal(nil)
+			close(methods)
+
+			names := make([]string, 0, 1)
+
+			for m := range methods {
+				names = append(names, m.Name)
+			}
+
+			g.Assert(names).Equal([]string{"CreateBooks"})
+			g.Assert(strings.Contains(output.String(), "CreateBooksContext")).Equal(false)
+			_, e = parser.ParseFile(token.NewFileSet(), "", output, parser.AllErrors)
+			g.Assert(e).Equal(nil)
+		})
+	})
+}
@@ -4,6 +4,7 @@ import "io"
 import "fmt"
 import "net/url"
 import "strings"
+import "go/types"
 import "github.com/gedex/inflector"
 import "github.com/dadleyy/marlow/marlow/writing"
 import "github.com/dadleyy/marlow/marlow/constants"
@@ -21,12 +22,18 @@ type createableSymbolList struct {
 	execError                string
 	affectedResult           string
 	affectedError            string
+	now                      string
 
 	recordIndex string
 }
 
 // newCreateableGenerator returns a reader that will generate a record store's creation api.
 func newCreateableGenerator(record marlowRecord) io.Reader {
+	return io.MultiReader(creator(record), returningCreator(record))
+}
+
+// creator returns a reader that will generate the Create<Records> method, inserting every record in a single statement.
+func creator(record marlowRecord) io.Reader {
 	pr, pw := io.Pipe()
 	methodName := fmt.Sprintf("Create%s", inflector.Pluralize(record.name()))
 
@@ -43,6 +50,7 @@ func newCreateableGenerator(record marlowRecord) io.Reader {
 		execError:                "_execError",
 		affectedResult:           "_affectedResult",
 		affectedError:            "_affectedError",
+		now:                      "_now",
 		recordIndex:              "_",
 	}
 
@@ -55,8 +63,12 @@ func newCreateableGenerator(record marlowRecord) io.Reader {
 		"error",
 	}
 
-	if record.dialect() == "postgres" && record.primaryKeyColumn() == "" {
-		pw.CloseWithError(fmt.Errorf("postgres records are required to have a primaryKey defined"))
+	// Dialects that read the inserted key back using a RETURNING clause need to know which column to return.
+	returning := record.dialect().Returning(record.quote(record.primaryKeyColumn()))
+
+	if returning != "" && record.primaryKeyColumn() == "" {
+		dialectName := record.config.Get(constants.DialectConfigOption)
+		pw.CloseWithError(fmt.Errorf("%s records are required to have a primaryKey defined", dialectName))
 		return pr
 	}
 
@@ -65,85 +77,25 @@ func newCreateableGenerator(record marlowRecord) io.Reader {
 
 		gosrc.Comment("[marlow] createable")
 
-		if record.dialect() == "postgres" {
+		if record.dialect().NumberedPlaceholders() {
 			symbols.recordIndex = "_recordIndex"
 		}
 
-		e := gosrc.WithMethod(methodName, record.store(), params, returns, func(scope url.Values) error {
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
 			logwriter := logWriter{output: gosrc, receiver: scope.Get("receiver")}
 
 			gosrc.WithIf("len(%s) == 0", func(url.Values) error {
 				return gosrc.Returns("0", writing.Nil)
 			}, symbols.recordParam)
 
-			columns := make([]string, 0, len(record.fields))
-			placeholders := make([]string, 0, len(record.fields))
-			index := 1
-
-			// Skip fields that have the `autoIncrement` directive.
-			fields := record.fieldList(func(config url.Values) bool {
-				return config.Get(constants.ColumnAutoIncrementFlag) == ""
-			})
-
-			for _, field := range fields {
-				placeholder := "?"
-
-				if record.dialect() == "postgres" {
-					fmtStr := "fmt.Sprintf(\"$%%d\", (%s*%d)+%d)"
-					placeholder = fmt.Sprintf(fmtStr, symbols.recordIndex, len(fields), index)
-				}
-
-				columns = append(columns, strings.Split(field.column, ".")[1])
-				placeholders = append(placeholders, placeholder)
-				index++
-			}
-
-			gosrc.Println("%s := make([]string, 0, len(%s))", symbols.statementPlaceholderList, symbols.recordParam)
-			gosrc.Println("%s := make([]interface{}, 0, len(%s))", symbols.statementValueList, symbols.recordParam)
-
-			gosrc.WithIter("%s, %s := range %s", func(url.Values) error {
-				if record.dialect() == "postgres" {
-					gosrc.Println("%s := []string{%s}", symbols.rowValueString, strings.Join(placeholders, ", "))
-				} else {
-					gosrc.Println("%s := %s", symbols.rowValueString, writing.StringSliceLiteral(placeholders))
-				}
-
-				fieldReferences := make([]string, 0, len(placeholders))
-
-				for _, field := range fields {
-					config := record.fields[field.name]
-
-					if config.Get(constants.ColumnAutoIncrementFlag) != "" {
-						continue
-					}
-
-					fieldReferences = append(fieldReferences, fmt.Sprintf("%s.%s", symbols.singleRecord, field.name))
-				}
-
-				gosrc.Println(
-					"%s = append(%s, %s)",
-					symbols.statementValueList,
-					symbols.statementValueList,
-					strings.Join(fieldReferences, ","),
-				)
-
-				return gosrc.Println(
-					"%s = append(%s, fmt.Sprintf(\"(%%s)\", strings.Join(%s, \",\")))",
-					symbols.statementPlaceholderList,
-					symbols.statementPlaceholderList,
-					symbols.rowValueString,
-				)
-			}, symbols.recordIndex, symbols.singleRecord, symbols.recordParam)
+			writeCurrentTime(gosrc, record, scope.Get("receiver"), symbols.now, timestampFlags...)
+			columns := writeInsertRows(gosrc, record, symbols)
 
 			gosrc.Println("%s := new(bytes.Buffer)", symbols.queryBuffer)
 
-			insertStatement := fmt.Sprintf("INSERT INTO %s (%s) VALUES %%s;", record.table(), strings.Join(columns, ","))
-
-			if record.dialect() == "postgres" {
-				template := "INSERT INTO %s (%s) VALUES %%s RETURNING %s;"
-				primary := record.primaryKeyColumn()
-				insertStatement = fmt.Sprintf(template, record.table(), strings.Join(columns, ","), primary)
-			}
+			table := record.quote(record.table())
+			template := "INSERT INTO %s (%s) VALUES %%s%s;"
+			insertStatement := fmt.Sprintf(template, table, strings.Join(columns, ","), returning)
 
 			gosrc.Println(
 				"fmt.Fprintf(%s, \"%s\", strings.Join(%s, \", \"))\n",
@@ -155,10 +107,11 @@ func newCreateableGenerator(record marlowRecord) io.Reader {
 			logwriter.AddLog(symbols.queryBuffer, symbols.statementValueList)
 
 			gosrc.Println(
-				"%s, %s := %s.Prepare(%s.String())",
+				"%s, %s := %s.PrepareContext(%s, %s.String())",
 				symbols.statement,
 				symbols.statementError,
 				scope.Get("receiver"),
+				ctx,
 				symbols.queryBuffer,
 			)
 
@@ -168,19 +121,19 @@ func newCreateableGenerator(record marlowRecord) io.Reader {
 
 			gosrc.Println("defer %s.Close()\n", symbols.statement)
 
-			execution := "%s, %s := %s.Exec(%s...)"
+			execution := "%s, %s := %s.ExecContext(%s, %s...)"
 
-			if record.dialect() == "postgres" {
-				execution = "%s, %s := %s.Query(%s...)"
+			if returning != "" {
+				execution = "%s, %s := %s.QueryContext(%s, %s...)"
 			}
 
-			gosrc.Println(execution, symbols.execResult, symbols.execError, symbols.statement, symbols.statementValueList)
+			gosrc.Println(execution, symbols.execResult, symbols.execError, symbols.statement, ctx, symbols.statementValueList)
 
 			gosrc.WithIf("%s != nil", func(url.Values) error {
 				return gosrc.Returns("-1", symbols.execError)
 			}, symbols.execError)
 
-			if record.dialect() != "postgres" {
+			if returning == "" {
 				gosrc.Println("%s, %s := %s.LastInsertId()", symbols.affectedResult, symbols.affectedError, symbols.execResult)
 				return gosrc.Returns(symbols.affectedResult, symbols.affectedError)
 			}
@@ -204,11 +157,6 @@ func newCreateableGenerator(record marlowRecord) io.Reader {
 
 		if e == nil {
 			record.registerImports("fmt", "bytes", "strings")
-			record.registerStoreMethod(writing.FuncDecl{
-				Name:    methodName,
-				Params:  params,
-				Returns: returns,
-			})
 		}
 
 		pw.CloseWithError(e)
@@ -216,3 +164,261 @@ func newCreateableGenerator(record marlowRecord) io.Reader {
 
 	return pr
 }
+
+// returningCreator returns a reader that will generate the Create<Records>Returning method. Records are inserted one at
+// a time using a single prepared statement so that the primary keys can be returned in the same order the records were
+// provided; auto increment keys are also assigned back onto the records.
+func returningCreator(record marlowRecord) io.Reader {
+	pr, pw := io.Pipe()
+
+	keyField, keyConfig, ok := record.primaryKeyField()
+
+	if !ok {
+		pw.CloseWithError(nil)
+		return pr
+	}
+
+	methodName := fmt.Sprintf("Create%sReturning", inflector.Pluralize(record.name()))
+	keyType := keyConfig.Get("type")
+	returning := record.dialect().Returning(record.quote(keyConfig.Get(constants.ColumnConfigOption)))
+
+	// Without a RETURNING clause the key comes from LastInsertId, which is only meaningful for integer keys; the method
+	// is not generated for records with other keys.
+	if returning == "" && fieldTypeInfo(keyConfig)&types.IsInteger == 0 {
+		pw.CloseWithError(nil)
+		return pr
+	}
+
+	// Keys that are not generated by the database are provided by the caller; those are never overwritten.
+	autoIncrement := keyConfig.Get(constants.ColumnAutoIncrementFlag) != ""
+
+	symbols := struct {
+		recordParam string
+		keys        string
+		key         string
+		lastID      string
+		record      string
+		values      string
+		query       string
+		statement   string
+		result      string
+		e           string
+		now         string
+	}{"_records", "_keys", "_key", "_lastID", "_record", "_values", "_query", "_statement", "_result", "_e", "_now"}
+
+	params := []writing.FuncParam{
+		{Symbol: symbols.recordParam, Type: fmt.Sprintf("...*%s", record.name())},
+	}
+
+	returns := []string{
+		fmt.Sprintf("[]%s", keyType),
+		"error",
+	}
+
+	fields := record.fieldList(func(config url.Values) bool {
+		return config.Get(constants.ColumnAutoIncrementFlag) == ""
+	})
+
+	columns := make([]string, 0, len(fields))
+	placeholders := make([]string, 0, len(fields))
+	positions := make([]string, 0, len(fields))
+	values := make([]string, 0, len(fields))
+
+	for i, field := range fields {
+		columns = append(columns, strings.Split(field.column, ".")[1])
+		placeholders = append(placeholders, "%s")
+		positions = append(positions, record.dialect().Placeholder(fmt.Sprintf("%d", i+1)))
+		reference := fmt.Sprintf("%s.%s", symbols.record, field.name)
+		values = append(values, fieldValue(record, record.fields[field.name], reference))
+	}
+
+	template := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)%s;",
+		record.quote(record.table()),
+		strings.Join(columns, ","),
+		strings.Join(placeholders, ","),
+		returning,
+	)
+
+	go func() {
+		gosrc := writing.NewGoWriter(pw)
+
+		gosrc.Comment("[marlow] createable (returning primary keys)")
+
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
+			logwriter := logWriter{output: gosrc, receiver: scope.Get("receiver")}
+
+			gosrc.Println("%s := make([]%s, 0, len(%s))", symbols.keys, keyType, symbols.recordParam)
+
+			gosrc.WithIf("len(%s) == 0", func(url.Values) error {
+				return gosrc.Returns(symbols.keys, writing.Nil)
+			}, symbols.recordParam)
+
+			gosrc.Println("%s := %s", symbols.query, sqlExpression(template, positions...))
+
+			gosrc.Println(
+				"%s, %s := %s.PrepareContext(%s, %s)",
+				symbols.statement,
+				symbols.e,
+				scope.Get("receiver"),
+				ctx,
+				symbols.query,
+			)
+
+			gosrc.WithIf("%s != nil", func(url.Values) error {
+				return gosrc.Returns(writing.Nil, symbols.e)
+			}, symbols.e)
+
+			gosrc.Println("defer %s.Close()\n", symbols.statement)
+
+			writeCurrentTime(gosrc, record, scope.Get("receiver"), symbols.now, timestampFlags...)
+
+			e := gosrc.WithIter("_, %s := range %s", func(url.Values) error {
+				gosrc.WithIf("%s == nil", func(url.Values) error {
+					return gosrc.Returns(writing.Nil, fmt.Sprintf("fmt.Errorf(\"%s\")", constants.InvalidCreateRecordError))
+				}, symbols.record)
+
+				writeEnumChecks(gosrc, record, symbols.record, func(e string) error {
+					return gosrc.Returns(writing.Nil, e)
+				})
+
+				writeTimestampDefaults(gosrc, record, symbols.record, symbols.now)
+
+				gosrc.Println("%s := []interface{}{%s}", symbols.values, strings.Join(values, ", "))
+
+				logwriter.AddLog(symbols.query, symbols.values)
+
+				if returning != "" {
+					gosrc.Println("var %s %s", symbols.key, keyType)
+
+					gosrc.WithIf(
+						"%s := %s.QueryRowContext(%s, %s...).Scan(&%s); %s != nil",
+						func(url.Values) error {
+							return gosrc.Returns(writing.Nil, symbols.e)
+						},
+						symbols.e,
+						symbols.statement,
+						ctx,
+						symbols.values,
+						symbols.key,
+						symbols.e,
+					)
+				} else {
+					gosrc.Println(
+						"%s, %s := %s.ExecContext(%s, %s...)",
+						symbols.result,
+						symbols.e,
+						symbols.statement,
+						ctx,
+						symbols.values,
+					)
+
+					gosrc.WithIf("%s != nil", func(url.Values) error {
+						return gosrc.Returns(writing.Nil, symbols.e)
+					}, symbols.e)
+
+					if !autoIncrement {
+						return gosrc.Println("%s = append(%s, %s.%s)", symbols.keys, symbols.keys, symbols.record, keyField)
+					}
+
+					gosrc.Println("%s, %s := %s.LastInsertId()", symbols.lastID, symbols.e, symbols.result)
+
+					gosrc.WithIf("%s != nil", func(url.Values) error {
+						return gosrc.Returns(writing.Nil, symbols.e)
+					}, symbols.e)
+
+					gosrc.Println("%s := %s(%s)", symbols.key, keyType, symbols.lastID)
+				}
+
+				if autoIncrement {
+					gosrc.Println("%s.%s = %s", symbols.record, keyField, symbols.key)
+				}
+
+				return gosrc.Println("%s = append(%s, %s)", symbols.keys, symbols.keys, symbols.key)
+			}, symbols.record, symbols.recordParam)
+
+			if e != nil {
+				return e
+			}
+
+			return gosrc.Returns(symbols.keys, writing.Nil)
+		})
+
+		if e == nil {
+			record.registerImports("fmt")
+		}
+
+		pw.CloseWithError(e)
+	}()
+
+	return pr
+}
+
+// writeInsertRows writes the loop that builds the placeholder groups & values sent alongside an INSERT statement for
+// each of the records in the record param. The returned (quoted) columns are in the same order as the values. Timestamp
+// fields flagged with any of the overwritten flags are set to the current time even if the record provides a value.
+func writeInsertRows(
+	gosrc writing.GoWriter,
+	record marlowRecord,
+	symbols createableSymbolList,
+	overwritten ...string,
+) []string {
+	columns := make([]string, 0, len(record.fields))
+	placeholders := make([]string, 0, len(record.fields))
+	index := 1
+
+	// Skip fields that have the `autoIncrement` directive.
+	fields := record.fieldList(func(config url.Values) bool {
+		return config.Get(constants.ColumnAutoIncrementFlag) == ""
+	})
+
+	for _, field := range fields {
+		placeholder := record.dialect().Placeholder(fmt.Sprintf("(%s*%d)+%d", symbols.recordIndex, len(fields), index))
+
+		columns = append(columns, strings.Split(field.column, ".")[1])
+		placeholders = append(placeholders, placeholder)
+		index++
+	}
+
+	gosrc.Println("%s := make([]string, 0, len(%s))", symbols.statementPlaceholderList, symbols.recordParam)
+	gosrc.Println("%s := make([]interface{}, 0, len(%s))", symbols.statementValueList, symbols.recordParam)
+
+	gosrc.WithIter("%s, %s := range %s", func(url.Values) error {
+		writeEnumChecks(gosrc, record, symbols.singleRecord, func(e string) error {
+			return gosrc.Returns("-1", e)
+		})
+
+		writeTimestampDefaults(gosrc, record, symbols.singleRecord, symbols.now, overwritten...)
+
+		gosrc.Println("%s := []string{%s}", symbols.rowValueString, strings.Join(placeholders, ", "))
+
+		fieldReferences := make([]string, 0, len(placeholders))
+
+		for _, field := range fields {
+			config := record.fields[field.name]
+
+			if config.Get(constants.ColumnAutoIncrementFlag) != "" {
+				continue
+			}
+
+			reference := fmt.Sprintf("%s.%s", symbols.singleRecord, field.name)
+			fieldReferences = append(fieldReferences, fieldValue(record, config, reference))
+		}
+
+		gosrc.Println(
+			"%s = append(%s, %s)",
+			symbols.statementValueList,
+			symbols.statementValueList,
+			strings.Join(fieldReferences, ","),
+		)
+
+		return gosrc.Println(
+			"%s = append(%s, fmt.Sprintf(\"(%%s)\", strings.Join(%s, \",\")))",
+			symbols.statementPlaceholderList,
+			symbols.statementPlaceholderList,
+			symbols.rowValueString,
+		)
+	}, symbols.recordIndex, symbols.singleRecord, symbols.recordParam)
+
+	return columns
+}
@@ -4,6 +4,7 @@ import "io"
 import "sync"
 import "bytes"
 import "testing"
+import "strings"
 import "net/url"
 import "github.com/franela/goblin"
 import "github.com/dadleyy/marlow/marlow/writing"
@@ -112,6 +113,61 @@ func Test_Createable(t *testing.T) {
 				g.Assert(e).Equal(nil)
 			})
 
+			g.Describe("with a primary key field", func() {
+				g.BeforeEach(func() {
+					scaffold.fields["ID"].Set(constants.ColumnConfigOption, "id")
+					scaffold.fields["ID"].Set(constants.PrimaryKeyColumnConfigOption, "true")
+					scaffold.fields["ID"].Set(constants.ColumnAutoIncrementFlag, "true")
+					scaffold.fields["Name"].Set(constants.ColumnConfigOption, "name")
+					scaffold.fields["UniversityID"].Set(constants.ColumnConfigOption, "university_id")
+				})
+
+				g.It("generates the key returning creation method", func() {
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "CreateAuthorsReturning(")).Equal(true)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "_record.ID = _key")).Equal(true)
+				})
+
+				g.It("omits the key returning creation method if the primary key is not an integer", func() {
+					scaffold.fields["ID"].Set("type", "string")
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "CreateAuthors(")).Equal(true)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "CreateAuthorsReturning")).Equal(false)
+				})
+
+				g.It("returns the provided keys without assigning them if the primary key is not auto incremented", func() {
+					scaffold.fields["ID"].Del(constants.ColumnAutoIncrementFlag)
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "_keys = append(_keys, _record.ID)")).Equal(true)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "_lastID")).Equal(false)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "_record.ID = _key")).Equal(false)
+				})
+
+				g.It("allows non-integer primary keys for dialects that support returning", func() {
+					scaffold.fields["ID"].Set("type", "string")
+					scaffold.record.Set(constants.DialectConfigOption, "postgres")
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "RETURNING id;")).Equal(true)
+				})
+
+				g.It("fills zero valued timestamp fields using the store's clock", func() {
+					scaffold.fields["CreatedAt"] = url.Values{
+						"type":                        []string{"time.Time"},
+						constants.ColumnConfigOption:  []string{"created_at"},
+						constants.ColumnCreatedAtFlag: []string{""},
+					}
+
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "_now := a.clock()")).Equal(true)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "if _record.CreatedAt.IsZero() {")).Equal(true)
+				})
+			})
+
 			g.Describe("with a postgres record dialect", func() {
 				g.BeforeEach(func() {
 					scaffold.record.Set(constants.DialectConfigOption, "postgres")
@@ -15,12 +15,37 @@ type deleteableSymbols struct {
 	statement      string
 	prepared       string
 	statementError string
+	scoped         string
+	now            string
+	values         string
 }
 
 // newDeleteableGenerator is responsible for creating a generator that will write out the Delete api methods.
 func newDeleteableGenerator(record marlowRecord) io.Reader {
+	table := record.quote(record.table())
+	deleteString := fmt.Sprintf("DELETE FROM %s", table)
+
+	// Records using soft deletion flag the rows as deleted (at the current time of the store) rather than removing them;
+	// they can later be restored.
+	if column := record.softDeleteColumn(); column != "" {
+		deleteString = fmt.Sprintf("UPDATE %s SET %s = %%s", table, record.quote(column))
+		restoreString := fmt.Sprintf("UPDATE %s SET %s = NULL", table, record.quote(column))
+
+		return io.MultiReader(
+			deleter(record, fmt.Sprintf("Delete%s", inflector.Pluralize(record.name())), deleteString, false),
+			deleter(record, fmt.Sprintf("Restore%s", inflector.Pluralize(record.name())), restoreString, true),
+		)
+	}
+
+	return deleter(record, fmt.Sprintf("Delete%s", inflector.Pluralize(record.name())), deleteString, false)
+}
+
+// deleter writes a store method that executes the provided statement using the where clause of a blueprint. Blueprints
+// that do not provide any limiting clauses of their own are rejected. When deleted is true, the statement is scoped to
+// the soft deleted records. The soft deletion statement of records using soft deletion receives the current time of
+// the store as the value of its placeholder.
+func deleter(record marlowRecord, methodName, statement string, deleted bool) io.Reader {
 	pr, pw := io.Pipe()
-	methodName := fmt.Sprintf("Delete%s", inflector.Pluralize(record.name()))
 
 	symbols := deleteableSymbols{
 		e:              "_e",
@@ -30,8 +55,13 @@ func newDeleteableGenerator(record marlowRecord) io.Reader {
 		prepared:       "_statement",
 		statementError: "_se",
 		result:         "_execResult",
+		scoped:         "_scoped",
+		now:            "_now",
+		values:         "_values",
 	}
 
+	stamped := record.softDeleteColumn() != "" && !deleted
+
 	params := []writing.FuncParam{
 		{Type: fmt.Sprintf("*%s", record.blueprint()), Symbol: symbols.blueprint},
 	}
@@ -44,20 +74,62 @@ func newDeleteableGenerator(record marlowRecord) io.Reader {
 	go func() {
 		gosrc := writing.NewGoWriter(pw)
 
-		gosrc.Comment("[marlow] deleteable")
+		gosrc.Comment("[marlow] deleteable (%s)", methodName)
 
-		e := gosrc.WithMethod(methodName, record.store(), params, returns, func(scope url.Values) error {
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
 			receiver := scope.Get("receiver")
 			logwriter := logWriter{receiver: receiver, output: gosrc}
 
-			gosrc.WithIf("%s == nil || %s.String() == \"\"", func(url.Values) error {
+			gosrc.WithIf("%s == nil || %s.where(1) == \"\"", func(url.Values) error {
 				return gosrc.Returns("-1", fmt.Sprintf("fmt.Errorf(\"%s\")", constants.InvalidDeletionBlueprint))
 			}, symbols.blueprint, symbols.blueprint)
 
-			deleteString := fmt.Sprintf("DELETE FROM %s", record.table())
-
-			gosrc.Println("%s := fmt.Sprintf(\"%s %%s\", %s)", symbols.statement, deleteString, symbols.blueprint)
-			gosrc.Println("%s, %s := %s.Prepare(%s + \";\")", symbols.prepared, symbols.e, receiver, symbols.statement)
+			if e := writeJoinGuard(gosrc, record, symbols.blueprint); e != nil {
+				return e
+			}
+
+			if deleted {
+				gosrc.Println("%s := *%s", symbols.scoped, symbols.blueprint)
+				gosrc.Println("%s.OnlyDeleted = true", symbols.scoped)
+				gosrc.Println("%s = &%s", symbols.blueprint, symbols.scoped)
+			}
+
+			values := fmt.Sprintf("%s.Values()", symbols.blueprint)
+
+			if !stamped {
+				gosrc.Println("%s := fmt.Sprintf(\"%s %%s\", %s)", symbols.statement, statement, symbols.blueprint)
+			}
+
+			// The deletion time is sent after the values of the blueprint when the dialect uses numbered placeholders.
+			if stamped {
+				gosrc.Println("%s := %s.%s()", symbols.now, receiver, constants.StoreClockField)
+				position := fmt.Sprintf("len(%s)+1", values)
+				placeholder := record.dialect().Placeholder(position)
+
+				gosrc.Println(
+					"%s := fmt.Sprintf(\"%s %%s\", %s, %s)",
+					symbols.statement,
+					statement,
+					placeholder,
+					symbols.blueprint,
+				)
+
+				if record.dialect().NumberedPlaceholders() {
+					gosrc.Println("%s := append(%s, %s)", symbols.values, values, symbols.now)
+				} else {
+					gosrc.Println("%s := append([]interface{}{%s}, %s...)", symbols.values, symbols.now, values)
+				}
+
+				values = symbols.values
+			}
+			gosrc.Println(
+				"%s, %s := %s.PrepareContext(%s, %s + \";\")",
+				symbols.prepared,
+				symbols.e,
+				receiver,
+				ctx,
+				symbols.statement,
+			)
 
 			// Check for preparation error.
 			gosrc.WithIf("%s != nil", func(url.Values) error { return gosrc.Returns("-1", symbols.e) }, symbols.e)
@@ -65,15 +137,16 @@ func newDeleteableGenerator(record marlowRecord) io.Reader {
 			// Always close the prepared statement.
 			gosrc.Println("defer %s.Close()", symbols.prepared)
 
-			logwriter.AddLog(symbols.statement, fmt.Sprintf("%s.Values()", symbols.blueprint))
+			logwriter.AddLog(symbols.statement, values)
 
 			// Executre the prepared statement with the values from the blueprint.
 			gosrc.Println(
-				"%s, %s := %s.Exec(%s.Values()...)",
+				"%s, %s := %s.ExecContext(%s, %s...)",
 				symbols.result,
 				symbols.e,
 				symbols.prepared,
-				symbols.blueprint,
+				ctx,
+				values,
 			)
 
 			// Check for statement.Exec error
@@ -90,11 +163,6 @@ func newDeleteableGenerator(record marlowRecord) io.Reader {
 
 		if e == nil {
 			record.registerImports("fmt")
-			record.registerStoreMethod(writing.FuncDecl{
-				Name:    methodName,
-				Returns: returns,
-				Params:  params,
-			})
 		}
 
 		pw.CloseWithError(e)
@@ -3,6 +3,7 @@ package marlow
 import "io"
 import "sync"
 import "bytes"
+import "strings"
 import "net/url"
 import "testing"
 import "github.com/franela/goblin"
@@ -101,6 +102,68 @@ func Test_Deleteable(t *testing.T) {
 				g.Assert(e).Equal(nil)
 			})
 
+			g.It("removes the rows from the table", func() {
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				g.Assert(strings.Contains(scaffold.buffer.String(), "DELETE FROM authors %s")).Equal(true)
+				g.Assert(strings.Contains(scaffold.buffer.String(), "RestoreAuthors")).Equal(false)
+			})
+
+			g.Describe("with a soft delete column", func() {
+				g.BeforeEach(func() {
+					scaffold.record.Set(constants.SoftDeleteConfigOption, "deleted_at")
+				})
+
+				g.It("flags the rows as deleted rather than removing them", func() {
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					s := scaffold.buffer.String()
+					g.Assert(strings.Contains(s, "DELETE FROM")).Equal(false)
+					g.Assert(strings.Contains(s, "UPDATE authors SET deleted_at = %s %s\", \"?\", _blueprint)")).Equal(true)
+				})
+
+				g.It("sends the current time of the store as the deletion time", func() {
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					s := scaffold.buffer.String()
+					g.Assert(strings.Contains(s, "CURRENT_TIMESTAMP")).Equal(false)
+					g.Assert(strings.Contains(s, "_now := a.clock()")).Equal(true)
+					g.Assert(strings.Contains(s, "_values := append([]interface{}{_now}, _blueprint.Values()...)")).Equal(true)
+					g.Assert(strings.Contains(s, "ExecContext(_ctx, _values...)")).Equal(true)
+				})
+
+				g.It("sends the deletion time after the blueprint values with numbered placeholders", func() {
+					scaffold.record.Set(constants.DialectConfigOption, "postgres")
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					s := scaffold.buffer.String()
+					g.Assert(strings.Contains(s, "fmt.Sprintf(\"$%d\", len(_blueprint.Values())+1)")).Equal(true)
+					g.Assert(strings.Contains(s, "_values := append(_blueprint.Values(), _now)")).Equal(true)
+				})
+
+				g.It("generates the restore method, scoped to the soft deleted rows", func() {
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					s := scaffold.buffer.String()
+					g.Assert(strings.Contains(s, "RestoreAuthors")).Equal(true)
+					g.Assert(strings.Contains(s, "UPDATE authors SET deleted_at = NULL %s")).Equal(true)
+					g.Assert(strings.Contains(s, "_scoped.OnlyDeleted = true")).Equal(true)
+				})
+			})
+
+			g.It("rejects blueprints filtering by related records when the record references another record", func() {
+				scaffold.fields["PublisherID"] = url.Values{
+					"type":                           []string{"int"},
+					"column":                         []string{"publisher"},
+					constants.ColumnReferencesOption: []string{"Publisher.ID"},
+				}
+
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				g.Assert(strings.Contains(scaffold.buffer.String(), "_blueprint.joins() != \"\"")).Equal(true)
+				g.Assert(strings.Contains(scaffold.buffer.String(), constants.InvalidJoinBlueprint)).Equal(true)
+			})
+
 		})
 
 	})
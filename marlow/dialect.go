@@ -0,0 +1,252 @@
+package marlow
+
+import "fmt"
+import "sort"
+import "strconv"
+import "strings"
+
+// Dialect implementations describe the sql syntax differences between the databases supported by marlow. Methods that
+// deal with placeholders and upserts return golang expressions; those are evaluated by the generated code at runtime.
+type Dialect interface {
+	// Placeholder returns a golang expression that evaluates to the query placeholder for the 1-based position expression.
+	Placeholder(position string) string
+
+	// NumberedPlaceholders returns true when the placeholders refer to values by position. When false, the values sent
+	// alongside a query must be in the same order that their placeholders appear in the query.
+	NumberedPlaceholders() bool
+
+	// QuoteIdentifier wraps a table or column name in the quote characters used by the dialect.
+	QuoteIdentifier(identifier string) string
+
+	// Returning returns the clause appended to INSERT statements in order to read back the primary key column. An empty
+	// string indicates the dialect relies on the LastInsertId method of the sql.Result instead.
+	Returning(column string) string
+
+	// NotNull returns the clause matching rows whose column is not null.
+	NotNull(column string) string
+
+	// Like returns the operator used for pattern matching against string columns.
+	Like() string
+
+	// ILike returns the format string (receiving the placeholder of a pattern) of the clause matching rows whose string
+	// column matches the pattern, ignoring case.
+	ILike(column string) string
+
+	// JSONContains returns the format string (receiving the placeholder of a json document) of the clause matching rows
+	// whose json column contains the document. An empty string indicates the dialect does not support json lookups.
+	JSONContains(column string) string
+
+	// JSONKeyEquals returns the format string (receiving the placeholders of a key & a value) of the clause matching rows
+	// whose json column holds the value at the key.
+	JSONKeyEquals(column string) string
+
+	// ArrayContains returns the format string (receiving the placeholder of an array) of the clause matching rows whose
+	// array column contains each of the elements of the array. An empty string indicates the dialect has no array columns.
+	ArrayContains(column string) string
+
+	// ArrayOverlaps returns the format string (receiving the placeholder of an array) of the clause matching rows whose
+	// array column has any of the elements of the array.
+	ArrayOverlaps(column string) string
+
+	// LimitOffset returns the format string (receiving the limit and then the offset) appended to select queries.
+	LimitOffset() string
+
+	// Upsert returns a golang expression that evaluates to the clause appended to an INSERT statement, updating the
+	// provided columns when the row already exists. The conflict parameter is a golang expression that evaluates to the
+	// comma separated list of columns used to detect the conflict. The assignments (e.g. `version = version + 1`) are
+	// appended to the updated columns as they are.
+	Upsert(conflict string, columns []string, assignments ...string) string
+
+	// UpsertConflictTarget returns false when the dialect detects upsert conflicts using every unique key of the table;
+	// the conflict columns are then ignored by the clause returned from Upsert.
+	UpsertConflictTarget() bool
+}
+
+// dialects is the registry of supported dialects, keyed by the value of the `dialect` record config option.
+var dialects = map[string]Dialect{
+	"":         &sqlDialect{},
+	"sql":      &sqlDialect{},
+	"sqlite":   &sqlDialect{},
+	"postgres": &postgresDialect{},
+	"mysql":    &mysqlDialect{},
+}
+
+func lookupDialect(name string) (Dialect, error) {
+	d, ok := dialects[name]
+
+	if !ok {
+		names := make([]string, 0, len(dialects))
+
+		for n := range dialects {
+			if n != "" {
+				names = append(names, n)
+			}
+		}
+
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown dialect \"%s\" (expected one of: %s)", name, strings.Join(names, ", "))
+	}
+
+	return d, nil
+}
+
+// sqlExpression returns a golang expression that evaluates to the format string with each `%s` verb replaced by the
+// corresponding placeholder expression. When every placeholder is a constant the expression is a plain string literal.
+func sqlExpression(format string, placeholders ...string) string {
+	constants := make([]interface{}, 0, len(placeholders))
+
+	for _, p := range placeholders {
+		value, e := strconv.Unquote(p)
+
+		if e != nil {
+			return fmt.Sprintf("fmt.Sprintf(%s, %s)", strconv.Quote(format), strings.Join(placeholders, ", "))
+		}
+
+		constants = append(constants, value)
+	}
+
+	return strconv.Quote(fmt.Sprintf(format, constants...))
+}
+
+// sqlDialect is the default dialect, using `?` placeholders and unquoted identifiers (e.g. sqlite).
+type sqlDialect struct {
+}
+
+func (d *sqlDialect) Placeholder(string) string {
+	return "\"?\""
+}
+
+func (d *sqlDialect) NumberedPlaceholders() bool {
+	return false
+}
+
+func (d *sqlDialect) QuoteIdentifier(identifier string) string {
+	return identifier
+}
+
+func (d *sqlDialect) Returning(string) string {
+	return ""
+}
+
+func (d *sqlDialect) NotNull(column string) string {
+	return fmt.Sprintf("%s NOT NULL", column)
+}
+
+func (d *sqlDialect) Like() string {
+	return "LIKE"
+}
+
+func (d *sqlDialect) ILike(column string) string {
+	return fmt.Sprintf("LOWER(%s) LIKE LOWER(%%s)", column)
+}
+
+func (d *sqlDialect) JSONContains(string) string {
+	return ""
+}
+
+func (d *sqlDialect) JSONKeyEquals(string) string {
+	return ""
+}
+
+func (d *sqlDialect) ArrayContains(string) string {
+	return ""
+}
+
+func (d *sqlDialect) ArrayOverlaps(string) string {
+	return ""
+}
+
+func (d *sqlDialect) LimitOffset() string {
+	return " LIMIT %d OFFSET %d"
+}
+
+func (d *sqlDialect) Upsert(conflict string, columns []string, assignments ...string) string {
+	updates := make([]string, 0, len(columns)+len(assignments))
+
+	for _, c := range columns {
+		updates = append(updates, fmt.Sprintf("%s = excluded.%s", c, c))
+	}
+
+	updates = append(updates, assignments...)
+	format := fmt.Sprintf(" ON CONFLICT (%%s) DO UPDATE SET %s", strings.Join(updates, ", "))
+	return fmt.Sprintf("fmt.Sprintf(%s, %s)", strconv.Quote(format), conflict)
+}
+
+func (d *sqlDialect) UpsertConflictTarget() bool {
+	return true
+}
+
+// postgresDialect uses numbered (`$1`) placeholders and reads inserted keys back with a RETURNING clause.
+type postgresDialect struct {
+	sqlDialect
+}
+
+func (d *postgresDialect) Placeholder(position string) string {
+	return fmt.Sprintf("fmt.Sprintf(\"$%%d\", %s)", position)
+}
+
+func (d *postgresDialect) NumberedPlaceholders() bool {
+	return true
+}
+
+func (d *postgresDialect) Returning(column string) string {
+	return fmt.Sprintf(" RETURNING %s", column)
+}
+
+func (d *postgresDialect) NotNull(column string) string {
+	return fmt.Sprintf("%s IS NOT NULL", column)
+}
+
+func (d *postgresDialect) ILike(column string) string {
+	return fmt.Sprintf("%s ILIKE %%s", column)
+}
+
+func (d *postgresDialect) JSONContains(column string) string {
+	return fmt.Sprintf("%s @> %%s", column)
+}
+
+// JSONKeyEquals casts the key placeholder; the `->>` operator also accepts the integer indexes of json arrays.
+func (d *postgresDialect) JSONKeyEquals(column string) string {
+	return fmt.Sprintf("%s ->> %%s::text = %%s", column)
+}
+
+func (d *postgresDialect) ArrayContains(column string) string {
+	return fmt.Sprintf("%s @> %%s", column)
+}
+
+func (d *postgresDialect) ArrayOverlaps(column string) string {
+	return fmt.Sprintf("%s && %%s", column)
+}
+
+// mysqlDialect quotes identifiers with backticks and updates duplicate rows based on the table's unique keys.
+type mysqlDialect struct {
+	sqlDialect
+}
+
+func (d *mysqlDialect) QuoteIdentifier(identifier string) string {
+	return fmt.Sprintf("`%s`", identifier)
+}
+
+func (d *mysqlDialect) NotNull(column string) string {
+	return fmt.Sprintf("%s IS NOT NULL", column)
+}
+
+// Like uses a binary comparison; mysql's default collations would otherwise match case-insensitively.
+func (d *mysqlDialect) Like() string {
+	return "LIKE BINARY"
+}
+
+func (d *mysqlDialect) Upsert(_ string, columns []string, assignments ...string) string {
+	updates := make([]string, 0, len(columns)+len(assignments))
+
+	for _, c := range columns {
+		updates = append(updates, fmt.Sprintf("%s = VALUES(%s)", c, c))
+	}
+
+	updates = append(updates, assignments...)
+	return strconv.Quote(fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s", strings.Join(updates, ", ")))
+}
+
+func (d *mysqlDialect) UpsertConflictTarget() bool {
+	return false
+}
@@ -0,0 +1,90 @@
+package marlow
+
+import "testing"
+import "github.com/franela/goblin"
+
+func Test_Dialect(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("dialect registry", func() {
+		g.It("returns the default sql dialect for empty dialect names", func() {
+			d, e := lookupDialect("")
+			g.Assert(e).Equal(nil)
+			g.Assert(d.NumberedPlaceholders()).Equal(false)
+			g.Assert(d.Placeholder("_i")).Equal("\"?\"")
+		})
+
+		g.It("returns an error for unknown dialect names", func() {
+			_, e := lookupDialect("oracle")
+			g.Assert(e == nil).Equal(false)
+		})
+
+		g.It("returns numbered placeholders for the postgres dialect", func() {
+			d, e := lookupDialect("postgres")
+			g.Assert(e).Equal(nil)
+			g.Assert(d.NumberedPlaceholders()).Equal(true)
+			g.Assert(d.Placeholder("_i+1")).Equal("fmt.Sprintf(\"$%d\", _i+1)")
+			g.Assert(d.Returning("id")).Equal(" RETURNING id")
+		})
+
+		g.It("matches strings ignoring case using the dialect specific syntax", func() {
+			postgres, _ := lookupDialect("postgres")
+			g.Assert(postgres.ILike("name")).Equal("name ILIKE %s")
+
+			mysql, _ := lookupDialect("mysql")
+			g.Assert(mysql.ILike("`name`")).Equal("LOWER(`name`) LIKE LOWER(%s)")
+		})
+
+		g.It("only supports the json operators for the postgres dialect", func() {
+			postgres, _ := lookupDialect("postgres")
+			g.Assert(postgres.JSONContains("refs")).Equal("refs @> %s")
+			g.Assert(postgres.JSONKeyEquals("refs")).Equal("refs ->> %s::text = %s")
+
+			sqlite, _ := lookupDialect("sqlite")
+			g.Assert(sqlite.JSONContains("refs")).Equal("")
+			g.Assert(sqlite.JSONKeyEquals("refs")).Equal("")
+		})
+
+		g.It("only supports array columns for the postgres dialect", func() {
+			postgres, _ := lookupDialect("postgres")
+			g.Assert(postgres.ArrayContains("tags")).Equal("tags @> %s")
+			g.Assert(postgres.ArrayOverlaps("tags")).Equal("tags && %s")
+
+			mysql, _ := lookupDialect("mysql")
+			g.Assert(mysql.ArrayContains("tags")).Equal("")
+		})
+
+		g.It("quotes identifiers with backticks for the mysql dialect", func() {
+			d, e := lookupDialect("mysql")
+			g.Assert(e).Equal(nil)
+			g.Assert(d.QuoteIdentifier("key")).Equal("`key`")
+			g.Assert(d.NotNull("`books`.`series`")).Equal("`books`.`series` IS NOT NULL")
+		})
+
+		g.It("uses the dialect specific upsert syntax", func() {
+			sqlite, _ := lookupDialect("sqlite")
+			g.Assert(sqlite.Upsert("_c", []string{"name"})).Equal(
+				"fmt.Sprintf(\" ON CONFLICT (%s) DO UPDATE SET name = excluded.name\", _c)",
+			)
+
+			mysql, _ := lookupDialect("mysql")
+			g.Assert(mysql.Upsert("_c", []string{"`name`"})).Equal("\" ON DUPLICATE KEY UPDATE `name` = VALUES(`name`)\"")
+			g.Assert(mysql.Upsert("_c", []string{"`name`"}, "`version` = `books`.`version` + 1")).Equal(
+				"\" ON DUPLICATE KEY UPDATE `name` = VALUES(`name`), `version` = `books`.`version` + 1\"",
+			)
+			g.Assert(mysql.UpsertConflictTarget()).Equal(false)
+			g.Assert(sqlite.UpsertConflictTarget()).Equal(true)
+		})
+	})
+
+	g.Describe("sqlExpression", func() {
+		g.It("inlines constant placeholders into a string literal", func() {
+			g.Assert(sqlExpression("a > %s AND a < %s", "\"?\"", "\"?\"")).Equal("\"a > ? AND a < ?\"")
+		})
+
+		g.It("formats dynamic placeholders at runtime", func() {
+			expression := sqlExpression("a LIKE %s", "fmt.Sprintf(\"$%d\", _i)")
+			g.Assert(expression).Equal("fmt.Sprintf(\"a LIKE %s\", fmt.Sprintf(\"$%d\", _i))")
+		})
+	})
+}
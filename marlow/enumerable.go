@@ -0,0 +1,289 @@
+package marlow
+
+import "io"
+import "fmt"
+import "sort"
+import "regexp"
+import "strconv"
+import "strings"
+import "net/url"
+import "go/ast"
+import "go/types"
+import "github.com/dadleyy/marlow/marlow/writing"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+// enumConstantsConfigKey is the field config key holding the names of the constants an enum field is restricted to.
+const enumConstantsConfigKey = "enumConstants"
+
+var enumValueRegex = regexp.MustCompile("^[A-Za-z0-9][A-Za-z0-9_-]*$")
+
+// enumField returns true for the fields restricted to a fixed set of values using the `enum` option.
+func enumField(config url.Values) bool {
+	_, ok := config[constants.ColumnEnumOption]
+	return ok
+}
+
+// packageEnum holds the enum field of a package record that lists the values of an enum type.
+type packageEnum struct {
+	typeName string
+	record   string
+	field    string
+	values   string
+}
+
+// parseEnums returns the enum fields of the record declared by the struct, in the order they are declared.
+func parseEnums(structType *ast.StructType, recordName string) []packageEnum {
+	enums := make([]packageEnum, 0)
+
+	for _, f := range structType.Fields.List {
+		name, config, ok := parseField(f)
+
+		if !ok || !enumField(config) || config.Get(constants.ColumnConfigOption) == "-" {
+			continue
+		}
+
+		enums = append(enums, packageEnum{
+			typeName: strings.TrimPrefix(types.ExprString(f.Type), "*"),
+			record:   recordName,
+			field:    name,
+			values:   config.Get(constants.ColumnEnumOption),
+		})
+	}
+
+	return enums
+}
+
+// packageEnums returns the enum field generating the constants & IsValid method of each enum type used by the package
+// records; the first field of the type by file name & declaration order. Every other field of the type shares them.
+func packageEnums(records []packageRecord) map[string]packageEnum {
+	sorted := append([]packageRecord(nil), records...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].file < sorted[j].file
+	})
+
+	result := make(map[string]packageEnum)
+
+	for _, r := range sorted {
+		for _, enum := range r.enums {
+			if _, dupe := result[enum.typeName]; !dupe {
+				result[enum.typeName] = enum
+			}
+		}
+	}
+
+	return result
+}
+
+// splitEnumValue returns the name & explicit value of one of the values listed by an enum field, e.g. `low` & `1` for
+// the `low:1` value of an integer enum.
+func splitEnumValue(listed string) (string, string) {
+	parts := strings.SplitN(listed, ":", 2)
+
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+// enumValues returns the values listed by the `enum` option of a field; nil for fields using the declared constants.
+func enumValues(config url.Values) []string {
+	values := config.Get(constants.ColumnEnumOption)
+
+	if values == "" {
+		return nil
+	}
+
+	return strings.Split(values, ",")
+}
+
+// enumConstantName returns the name of the constant generated for one of the values listed by an enum field, e.g.
+// `StatusOnHold` for the `on_hold` value of a `Status` field.
+func enumConstantName(typeName, value string) string {
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == '_' || r == '-'
+	})
+
+	for i, p := range parts {
+		parts[i] = strings.ToUpper(p[0:1]) + p[1:]
+	}
+
+	return fmt.Sprintf("%s%s", typeName, strings.Join(parts, ""))
+}
+
+// parseEnum validates the `enum` option of a field, storing the names of the constants the field is restricted to in
+// the field config. The constants & IsValid method are generated alongside the type of the field, which must be a
+// string or integer type declared in the package of the record. Integer values must be listed with their explicit
+// value (e.g. `enum=low:1,high:2`), keeping the stored values stable when the list changes.
+func parseEnum(name string, config url.Values) error {
+	fieldType := config.Get("type")
+	valueType := strings.TrimPrefix(fieldType, "*")
+	underlying := getTypeInfo(config.Get("underlying"))
+
+	if config.Get("import") != "" || getTypeInfo(valueType) != 0 || underlying&(types.IsString|types.IsInteger) == 0 {
+		return fmt.Errorf("enum columns must be string or integer types of the package, %s has type \"%s\"", name, fieldType)
+	}
+
+	values := enumValues(config)
+
+	if len(values) == 0 {
+		if len(config[enumConstantsConfigKey]) == 0 {
+			return fmt.Errorf("no constants of type %s found for enum field %s", valueType, name)
+		}
+
+		return nil
+	}
+
+	numeric := underlying&types.IsInteger != 0
+	names, seen, assigned := make([]string, 0, len(values)), make(map[string]string), make(map[string]string)
+
+	for _, v := range values {
+		valueName, explicit := splitEnumValue(v)
+
+		if !enumValueRegex.MatchString(valueName) {
+			return fmt.Errorf("invalid enum value for %s: \"%s\"", name, v)
+		}
+
+		if _, e := strconv.ParseInt(explicit, 10, 64); numeric && e != nil {
+			example := fmt.Sprintf("%s:1", valueName)
+			return fmt.Errorf("integer enum values must be listed with their value (e.g. %s), %s lists \"%s\"", example, name, v)
+		}
+
+		if !numeric && explicit != "" {
+			return fmt.Errorf("string enum values must not be listed with a value, %s lists \"%s\"", name, v)
+		}
+
+		constant := enumConstantName(valueType, valueName)
+
+		if other, dupe := seen[constant]; dupe {
+			return fmt.Errorf("duplicate enum values for %s: %s & %s", name, other, v)
+		}
+
+		if other, dupe := assigned[explicit]; numeric && dupe {
+			return fmt.Errorf("duplicate enum values for %s: %s & %s", name, other, v)
+		}
+
+		seen[constant], assigned[explicit] = v, v
+		names = append(names, constant)
+	}
+
+	config[enumConstantsConfigKey] = names
+	return nil
+}
+
+// enumFailure callbacks write the return statement of the generated code for an invalid enum value, receiving the
+// expression of the error that is returned.
+type enumFailure func(string) error
+
+// writeEnumCheck writes the check of a value held by an enum field of the record, writing the return statement using
+// the fail callback when the value is not valid. Nil values of pointer fields are valid.
+func writeEnumCheck(
+	gosrc writing.GoWriter,
+	record marlowRecord,
+	config url.Values,
+	value string,
+	fail enumFailure,
+) error {
+	if !enumField(config) {
+		return nil
+	}
+
+	condition, display := fmt.Sprintf("%s.IsValid() != true", value), value
+
+	if pointerField(config) {
+		condition, display = fmt.Sprintf("%s != nil && %s", value, condition), fmt.Sprintf("*%s", value)
+	}
+
+	return gosrc.WithIf(condition, func(url.Values) error {
+		message := fmt.Sprintf(
+			"fmt.Errorf(\"%s for %s.%s: %%v\", %s)",
+			constants.InvalidEnumValueError,
+			record.table(),
+			config.Get(constants.ColumnConfigOption),
+			display,
+		)
+
+		return fail(message)
+	})
+}
+
+// writeEnumChecks writes the checks of the values held by each of the enum fields of the record symbol.
+func writeEnumChecks(gosrc writing.GoWriter, record marlowRecord, symbol string, fail enumFailure) error {
+	for _, f := range record.enumFields() {
+		value := fmt.Sprintf("%s.%s", symbol, f.name)
+
+		if e := writeEnumCheck(gosrc, record, record.fields[f.name], value, fail); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// enumeration returns a reader that generates the constants of the values listed by an enum field of the record (if
+// any) and the IsValid method of the field's type. String values are used as-is while integer values use the explicit
+// value listed with each of them.
+func enumeration(record marlowRecord, name string) io.Reader {
+	pr, pw := io.Pipe()
+	config := record.fields[name]
+	typeName := strings.TrimPrefix(config.Get("type"), "*")
+	names, values := config[enumConstantsConfigKey], enumValues(config)
+	numeric := getTypeInfo(config.Get("underlying"))&types.IsInteger != 0
+
+	go func() {
+		gosrc := writing.NewGoWriter(pw)
+
+		if len(values) > 0 {
+			gosrc.Comment("[marlow] enum values of %s.%s", record.name(), name)
+
+			for i, constant := range names {
+				valueName, explicit := splitEnumValue(values[i])
+				value := strconv.Quote(valueName)
+
+				if numeric {
+					value = explicit
+				}
+
+				gosrc.Println("const %s %s = %s", constant, typeName, value)
+			}
+
+			gosrc.Println("")
+		}
+
+		gosrc.Comment("[marlow] enum validation for %s.%s", record.name(), name)
+
+		e := gosrc.WithValueMethod("IsValid", typeName, nil, []string{"bool"}, func(scope url.Values) error {
+			comparisons := make([]string, 0, len(names))
+
+			for _, constant := range names {
+				comparisons = append(comparisons, fmt.Sprintf("%s == %s", scope.Get("receiver"), constant))
+			}
+
+			return gosrc.Returns(strings.Join(comparisons, " || "))
+		})
+
+		pw.CloseWithError(e)
+	}()
+
+	return pr
+}
+
+// newEnumerableGenerator returns a reader that generates the constants & validation of the record's enum fields. The
+// code of enum types shared with other fields is only generated by the field listing the values of the type.
+func newEnumerableGenerator(record marlowRecord) io.Reader {
+	fields := record.enumFields()
+	readers := make([]io.Reader, 0, len(fields))
+
+	for _, f := range fields {
+		owner, shared := record.packageEnums[fieldValueType(record.fields[f.name])]
+
+		if shared && (owner.record != record.name() || owner.field != f.name) {
+			continue
+		}
+
+		readers = append(readers, enumeration(record, f.name))
+	}
+
+	return io.MultiReader(readers...)
+}
@@ -0,0 +1,156 @@
+package marlow
+
+import "io"
+import "fmt"
+import "bytes"
+import "strings"
+import "testing"
+import "net/url"
+import "go/token"
+import "go/parser"
+import "github.com/franela/goblin"
+import "github.com/dadleyy/marlow/marlow/writing"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+func Test_Enumerable(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("enumConstantName", func() {
+		g.It("camel cases the value after the type name", func() {
+			g.Assert(enumConstantName("Status", "active")).Equal("StatusActive")
+			g.Assert(enumConstantName("Status", "on_hold")).Equal("StatusOnHold")
+			g.Assert(enumConstantName("Status", "in-print")).Equal("StatusInPrint")
+			g.Assert(enumConstantName("Level", "2fa")).Equal("Level2fa")
+		})
+	})
+
+	g.Describe("parseEnum", func() {
+		var config url.Values
+
+		g.BeforeEach(func() {
+			config = url.Values{"type": []string{"Level"}, "underlying": []string{"int"}}
+		})
+
+		g.It("requires the values of integer enums to be listed with their value", func() {
+			config.Set(constants.ColumnEnumOption, "low,high")
+			g.Assert(parseEnum("Level", config) == nil).Equal(false)
+			config.Set(constants.ColumnEnumOption, "low:1,high:two")
+			g.Assert(parseEnum("Level", config) == nil).Equal(false)
+			config.Set(constants.ColumnEnumOption, "low:1,high:-2")
+			g.Assert(parseEnum("Level", config)).Equal(nil)
+		})
+
+		g.It("returns an error if integer enum values share a value", func() {
+			config.Set(constants.ColumnEnumOption, "low:1,high:1")
+			g.Assert(parseEnum("Level", config) == nil).Equal(false)
+		})
+
+		g.It("returns an error if string enum values are listed with a value", func() {
+			config = url.Values{"type": []string{"Status"}, "underlying": []string{"string"}}
+			config.Set(constants.ColumnEnumOption, "draft:1")
+			g.Assert(parseEnum("Status", config) == nil).Equal(false)
+		})
+	})
+
+	g.Describe("packageEnums", func() {
+		g.It("returns the first field of each enum type by file name & declaration order", func() {
+			records := []packageRecord{
+				{file: "member.go", enums: []packageEnum{{typeName: "Status", record: "Member", field: "Status"}}},
+				{file: "book.go", enums: []packageEnum{
+					{typeName: "Status", record: "Book", field: "Previous"},
+					{typeName: "Status", record: "Book", field: "Status"},
+				}},
+			}
+
+			enums := packageEnums(records)
+			g.Assert(len(enums)).Equal(1)
+			g.Assert(enums["Status"].record).Equal("Book")
+			g.Assert(enums["Status"].field).Equal("Previous")
+		})
+	})
+
+	g.Describe("enumerable feature generator test suite", func() {
+		var record marlowRecord
+		var output *bytes.Buffer
+
+		g.BeforeEach(func() {
+			output = new(bytes.Buffer)
+			record = marlowRecord{
+				config: newRecordConfig("Book"),
+				fields: map[string]url.Values{
+					"Title": {"type": []string{"string"}},
+				},
+			}
+			fmt.Fprintln(output, "package marlowt")
+		})
+
+		g.It("does not generate anything without enum fields", func() {
+			_, e := io.Copy(output, newEnumerableGenerator(record))
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.TrimSpace(output.String())).Equal("package marlowt")
+		})
+
+		g.It("generates valid golang for the constants & validation of the enum fields", func() {
+			status := url.Values{"type": []string{"Status"}, "underlying": []string{"string"}}
+			status.Set(constants.ColumnEnumOption, "draft,in-print")
+			g.Assert(parseEnum("Status", status)).Equal(nil)
+			record.fields["Status"] = status
+
+			_, e := io.Copy(output, newEnumerableGenerator(record))
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "return s == StatusDraft || s == StatusInPrint")).Equal(true)
+
+			_, e = parser.ParseFile(token.NewFileSet(), "", output, parser.AllErrors)
+			g.Assert(e).Equal(nil)
+		})
+
+		g.It("uses the explicit values listed with the values of integer enums", func() {
+			level := url.Values{"type": []string{"Level"}, "underlying": []string{"uint8"}}
+			level.Set(constants.ColumnEnumOption, "low:10,high:20")
+			g.Assert(parseEnum("Level", level)).Equal(nil)
+			record.fields["Level"] = level
+
+			_, e := io.Copy(output, newEnumerableGenerator(record))
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "const LevelLow Level = 10")).Equal(true)
+			g.Assert(strings.Contains(output.String(), "const LevelHigh Level = 20")).Equal(true)
+		})
+
+		g.It("only generates the enum types shared with other records for the field listing their values", func() {
+			status := url.Values{"type": []string{"Status"}, "underlying": []string{"string"}}
+			status.Set(constants.ColumnEnumOption, "draft")
+			g.Assert(parseEnum("Status", status)).Equal(nil)
+			record.fields["Status"] = status
+			record.packageEnums = map[string]packageEnum{
+				"Status": {typeName: "Status", record: "Author", field: "Status", values: "draft"},
+			}
+
+			_, e := io.Copy(output, newEnumerableGenerator(record))
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.TrimSpace(output.String())).Equal("package marlowt")
+
+			record.packageEnums["Status"] = packageEnum{typeName: "Status", record: "Book", field: "Status"}
+			_, e = io.Copy(output, newEnumerableGenerator(record))
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "func (s Status) IsValid() bool")).Equal(true)
+		})
+
+		g.It("writes checks of the enum fields of the record that pass nil pointers", func() {
+			level := url.Values{"type": []string{"*Level"}, "underlying": []string{"uint8"}}
+			level.Set(constants.ColumnEnumOption, "low:1,high:2")
+			level.Set(constants.ColumnConfigOption, "level")
+			g.Assert(parseEnum("Level", level)).Equal(nil)
+			record.fields["Level"] = level
+
+			gosrc := writing.NewGoWriter(output)
+			e := writeEnumChecks(gosrc, record, "_record", func(e string) error {
+				return gosrc.Returns(e)
+			})
+
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "_record.Level != nil && _record.Level.IsValid() != true")).Equal(true)
+			g.Assert(strings.Contains(output.String(), "invalid enum value for books.level: %v\", *_record.Level")).Equal(true)
+			g.Assert(strings.Contains(output.String(), "_record.Title")).Equal(false)
+		})
+	})
+}
@@ -0,0 +1,283 @@
+package marlow
+
+import "io"
+import "fmt"
+import "strings"
+import "net/url"
+import "github.com/dadleyy/marlow/marlow/writing"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+// jsonField returns true for the fields flagged with `json`, whose values are stored as json documents.
+func jsonField(config url.Values) bool {
+	_, ok := config[constants.ColumnJSONFlag]
+	return ok
+}
+
+// jsonValueType returns the name of the type generated for the record that marshals the values of its json fields when
+// they are sent to the database (as a driver.Valuer) and unmarshals them when they are scanned (as a sql.Scanner).
+func jsonValueType(record marlowRecord) string {
+	name := record.name()
+	return fmt.Sprintf("%s%sJSON", strings.ToLower(name[0:1]), name[1:])
+}
+
+// fieldValue returns the expression of a field's value used when sending it to, or scanning it from, the database; the
+// values of json fields are wrapped by the json value type of the record & array fields are encoded using pq.Array.
+func fieldValue(record marlowRecord, config url.Values, reference string) string {
+	if arrayField(config) {
+		return fmt.Sprintf("pq.Array(%s)", reference)
+	}
+
+	if !jsonField(config) {
+		return reference
+	}
+
+	return fmt.Sprintf("%s{%s}", jsonValueType(record), reference)
+}
+
+// jsonMethods returns the readers generating the json clause methods of a json field. Json fields are only matched
+// using the json operators of the record's dialect; no clauses are generated for dialects without them.
+func jsonMethods(record marlowRecord, fieldName string, fieldConfig url.Values, methods chan<- string) []io.Reader {
+	column := fieldConfig.Get(constants.ColumnConfigOption)
+
+	if record.dialect().JSONContains(column) == "" {
+		return nil
+	}
+
+	return []io.Reader{
+		jsonContains(record, fieldName, fieldConfig, methods),
+		jsonKeyEquals(record, fieldName, fieldConfig, methods),
+	}
+}
+
+// jsonContains generates the clause method matching the rows whose json column contains each of the documents
+// of the field's contains lookup.
+func jsonContains(record marlowRecord, fieldName string, fieldConfig url.Values, methods chan<- string) io.Reader {
+	pr, pw := io.Pipe()
+	columnName := fieldConfig.Get(constants.ColumnConfigOption)
+	methodName := fmt.Sprintf("%sContainsString", columnName)
+	containsSuffix := record.config.Get(constants.BlueprintJSONContainsFieldSuffixConfigOption)
+	containsFieldName := fmt.Sprintf("%s%s", fieldName, containsSuffix)
+	columnReference := record.columnReference(columnName)
+
+	symbols := struct {
+		conjunction string
+		clauses     string
+		item        string
+		values      string
+		count       string
+		index       string
+	}{"_conjunc", "_clauses", "_value", "_values", "_count", "_i"}
+
+	if !record.dialect().NumberedPlaceholders() {
+		symbols.index = "_"
+	}
+
+	returns := []string{"string", "[]interface{}"}
+	params := []writing.FuncParam{
+		{Type: "int", Symbol: symbols.count},
+	}
+
+	write := func() {
+		writer := writing.NewGoWriter(pw)
+		writer.Comment("[marlow] json containment clause for \"%s\"", columnReference)
+
+		e := writer.WithMethod(methodName, record.blueprint(), params, returns, func(scope url.Values) error {
+			lookup := fmt.Sprintf("%s.%s", scope.Get("receiver"), containsFieldName)
+
+			writer.WithIf("len(%s) == 0", func(url.Values) error {
+				return writer.Returns(writing.EmptyString, writing.Nil)
+			}, lookup)
+
+			writer.Println("%s := make([]string, 0, len(%s))", symbols.clauses, lookup)
+			writer.Println("%s := make([]interface{}, 0, len(%s))", symbols.values, lookup)
+
+			writer.WithIter("%s, %s := range %s", func(url.Values) error {
+				placeholder := record.dialect().Placeholder(fmt.Sprintf("%s+%s", symbols.count, symbols.index))
+				clause := sqlExpression(record.dialect().JSONContains(columnReference), placeholder)
+
+				writer.Println("%s = append(%s, %s)", symbols.clauses, symbols.clauses, clause)
+				value := fieldValue(record, fieldConfig, symbols.item)
+				return writer.Println("%s = append(%s, %s)", symbols.values, symbols.values, value)
+			}, symbols.index, symbols.item, lookup)
+
+			writer.Println("%s := \" AND \"", symbols.conjunction)
+
+			writer.WithIf("%s.Inclusive == true", func(url.Values) error {
+				return writer.Println("%s = \" OR \"", symbols.conjunction)
+			}, scope.Get("receiver"))
+
+			clauseString := fmt.Sprintf("strings.Join(%s, %s)", symbols.clauses, symbols.conjunction)
+			return writer.Returns(clauseString, symbols.values)
+		})
+
+		if e == nil {
+			methods <- methodName
+		}
+
+		pw.CloseWithError(e)
+	}
+
+	go write()
+
+	return pr
+}
+
+// jsonKeyEquals generates the clause method matching the rows whose json column holds each of the values of the key
+// lookup of the field at their keys. The keys are sorted, keeping the order of the clauses & their values stable.
+func jsonKeyEquals(record marlowRecord, fieldName string, fieldConfig url.Values, methods chan<- string) io.Reader {
+	pr, pw := io.Pipe()
+	columnName := fieldConfig.Get(constants.ColumnConfigOption)
+	methodName := fmt.Sprintf("%sKeyString", columnName)
+	keySuffix := record.config.Get(constants.BlueprintJSONKeyFieldSuffixConfigOption)
+	keyFieldName := fmt.Sprintf("%s%s", fieldName, keySuffix)
+	columnReference := record.columnReference(columnName)
+
+	symbols := struct {
+		conjunction string
+		clauses     string
+		keys        string
+		key         string
+		values      string
+		count       string
+	}{"_conjunc", "_clauses", "_keys", "_key", "_values", "_count"}
+
+	returns := []string{"string", "[]interface{}"}
+	params := []writing.FuncParam{
+		{Type: "int", Symbol: symbols.count},
+	}
+
+	write := func() {
+		writer := writing.NewGoWriter(pw)
+		writer.Comment("[marlow] json key clause for \"%s\"", columnReference)
+
+		e := writer.WithMethod(methodName, record.blueprint(), params, returns, func(scope url.Values) error {
+			lookup := fmt.Sprintf("%s.%s", scope.Get("receiver"), keyFieldName)
+
+			writer.WithIf("len(%s) == 0", func(url.Values) error {
+				return writer.Returns(writing.EmptyString, writing.Nil)
+			}, lookup)
+
+			writer.Println("%s := make([]string, 0, len(%s))", symbols.keys, lookup)
+
+			writer.WithIter("%s := range %s", func(url.Values) error {
+				return writer.Println("%s = append(%s, %s)", symbols.keys, symbols.keys, symbols.key)
+			}, symbols.key, lookup)
+
+			writer.Println("sort.Strings(%s)", symbols.keys)
+			writer.Println("%s := make([]string, 0, len(%s))", symbols.clauses, symbols.keys)
+			writer.Println("%s := make([]interface{}, 0, len(%s)*2)", symbols.values, symbols.keys)
+
+			writer.WithIter("_, %s := range %s", func(url.Values) error {
+				clause := sqlExpression(
+					record.dialect().JSONKeyEquals(columnReference),
+					record.dialect().Placeholder(fmt.Sprintf("%s+len(%s)", symbols.count, symbols.values)),
+					record.dialect().Placeholder(fmt.Sprintf("%s+len(%s)+1", symbols.count, symbols.values)),
+				)
+
+				writer.Println("%s = append(%s, %s)", symbols.clauses, symbols.clauses, clause)
+				value := fmt.Sprintf("%s[%s]", lookup, symbols.key)
+				return writer.Println("%s = append(%s, %s, %s)", symbols.values, symbols.values, symbols.key, value)
+			}, symbols.key, symbols.keys)
+
+			writer.Println("%s := \" AND \"", symbols.conjunction)
+
+			writer.WithIf("%s.Inclusive == true", func(url.Values) error {
+				return writer.Println("%s = \" OR \"", symbols.conjunction)
+			}, scope.Get("receiver"))
+
+			clauseString := fmt.Sprintf("strings.Join(%s, %s)", symbols.clauses, symbols.conjunction)
+			return writer.Returns(clauseString, symbols.values)
+		})
+
+		if e == nil {
+			record.registerImports("sort")
+			methods <- methodName
+		}
+
+		pw.CloseWithError(e)
+	}
+
+	go write()
+
+	return pr
+}
+
+// newJSONGenerator returns a reader that generates the json value type of records with json fields. Values marshaling
+// to the json `null` are stored as NULL, while NULL columns are scanned into the zero value of the field.
+func newJSONGenerator(record marlowRecord) io.Reader {
+	pr, pw := io.Pipe()
+
+	if len(record.fieldList(jsonField)) == 0 {
+		pw.CloseWithError(nil)
+		return pr
+	}
+
+	typeName := jsonValueType(record)
+
+	symbols := struct {
+		data   string
+		source string
+		ok     string
+		e      string
+	}{"_data", "_source", "_ok", "_e"}
+
+	go func() {
+		gosrc := writing.NewGoWriter(pw)
+		gosrc.Comment("[marlow] json column values for %s", record.name())
+
+		gosrc.WithStruct(typeName, func(url.Values) error {
+			return gosrc.Println("value interface{}")
+		})
+
+		returns := []string{"driver.Value", "error"}
+
+		e := gosrc.WithValueMethod("Value", typeName, nil, returns, func(scope url.Values) error {
+			gosrc.Println("%s, %s := json.Marshal(%s.value)", symbols.data, symbols.e, scope.Get("receiver"))
+
+			gosrc.WithIf("%s != nil", func(url.Values) error {
+				return gosrc.Returns(writing.Nil, symbols.e)
+			}, symbols.e)
+
+			gosrc.WithIf("string(%s) == \"null\"", func(url.Values) error {
+				return gosrc.Returns(writing.Nil, writing.Nil)
+			}, symbols.data)
+
+			return gosrc.Returns(fmt.Sprintf("string(%s)", symbols.data), writing.Nil)
+		})
+
+		if e != nil {
+			pw.CloseWithError(e)
+			return
+		}
+
+		params := []writing.FuncParam{
+			{Type: "interface{}", Symbol: symbols.source},
+		}
+
+		e = gosrc.WithValueMethod("Scan", typeName, params, []string{"error"}, func(scope url.Values) error {
+			target := fmt.Sprintf("%s.value", scope.Get("receiver"))
+
+			gosrc.WithIf("%s, %s := %s.([]byte); %s", func(url.Values) error {
+				return gosrc.Returns(fmt.Sprintf("json.Unmarshal(%s, %s)", symbols.data, target))
+			}, symbols.data, symbols.ok, symbols.source, symbols.ok)
+
+			gosrc.WithIf("%s, %s := %s.(string); %s", func(url.Values) error {
+				return gosrc.Returns(fmt.Sprintf("json.Unmarshal([]byte(%s), %s)", symbols.data, target))
+			}, symbols.data, symbols.ok, symbols.source, symbols.ok)
+
+			gosrc.WithIf("%s == nil", func(url.Values) error {
+				return gosrc.Returns(writing.Nil)
+			}, symbols.source)
+
+			return gosrc.Returns(fmt.Sprintf("fmt.Errorf(\"unsupported json column value: %%T\", %s)", symbols.source))
+		})
+
+		if e == nil {
+			record.registerImports("fmt", "encoding/json", "database/sql/driver")
+		}
+
+		pw.CloseWithError(e)
+	}()
+
+	return pr
+}
@@ -0,0 +1,84 @@
+package marlow
+
+import "io"
+import "fmt"
+import "bytes"
+import "strings"
+import "testing"
+import "net/url"
+import "go/token"
+import "go/parser"
+import "github.com/franela/goblin"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+func Test_JSON(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	g.Describe("fieldValue", func() {
+		record := marlowRecord{config: newRecordConfig("Genre")}
+
+		g.It("returns the reference of fields without the json flag as-is", func() {
+			g.Assert(fieldValue(record, url.Values{}, "_r.Name")).Equal("_r.Name")
+		})
+
+		g.It("wraps the reference of json fields using the json value type of the record", func() {
+			config := url.Values{constants.ColumnJSONFlag: []string{""}}
+			g.Assert(jsonValueType(record)).Equal("genreJSON")
+			g.Assert(fieldValue(record, config, "&_r.References")).Equal("genreJSON{&_r.References}")
+		})
+
+		g.It("encodes the values of array fields using pq.Array", func() {
+			config := url.Values{"type": []string{"[]string"}}
+			g.Assert(fieldValue(record, config, "&_r.Tags")).Equal("pq.Array(&_r.Tags)")
+		})
+	})
+
+	g.Describe("json feature generator test suite", func() {
+		var record marlowRecord
+		var output *bytes.Buffer
+		var imports chan string
+
+		g.BeforeEach(func() {
+			output = new(bytes.Buffer)
+			imports = make(chan string, 10)
+			record = marlowRecord{
+				config:        newRecordConfig("Genre"),
+				importChannel: imports,
+				fields: map[string]url.Values{
+					"Name": {"type": []string{"string"}},
+				},
+			}
+			fmt.Fprintln(output, "package marlowt")
+		})
+
+		g.It("does not generate anything without json fields", func() {
+			_, e := io.Copy(output, newJSONGenerator(record))
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.TrimSpace(output.String())).Equal("package marlowt")
+		})
+
+		g.It("generates a valid golang valuer & scanner for the json fields", func() {
+			record.fields["References"] = url.Values{
+				"type":                   []string{"map[string]string"},
+				constants.ColumnJSONFlag: []string{""},
+			}
+
+			_, e := io.Copy(output, newJSONGenerator(record))
+			g.Assert(e).Equal(nil)
+			close(imports)
+
+			received := make(map[string]bool)
+
+			for i := range imports {
+				received[i] = true
+			}
+
+			g.Assert(received["encoding/json"]).Equal(true)
+			g.Assert(received["database/sql/driver"]).Equal(true)
+			g.Assert(strings.Contains(output.String(), "func (g genreJSON) Scan(_source interface{}) error")).Equal(true)
+
+			_, e = parser.ParseFile(token.NewFileSet(), "", output, parser.AllErrors)
+			g.Assert(e).Equal(nil)
+		})
+	})
+}
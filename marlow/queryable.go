@@ -3,6 +3,7 @@ package marlow
 import "io"
 import "fmt"
 import "strings"
+import "strconv"
 import "net/url"
 import "github.com/gedex/inflector"
 import "github.com/dadleyy/marlow/marlow/writing"
@@ -20,6 +21,8 @@ type finderSymbols struct {
 	recordSlice     string
 	limit           string
 	offset          string
+	order           string
+	orderError      string
 }
 
 // finter builds a generator that is responsible for creating the FindRecord methods for a given record store.
@@ -50,6 +53,8 @@ func finder(record marlowRecord) io.Reader {
 		queryError:      "_qe",
 		limit:           "_limit",
 		offset:          "_offset",
+		order:           "_order",
+		orderError:      "_oe",
 		recordSlice:     fmt.Sprintf("[]*%s", record.name()),
 	}
 
@@ -71,9 +76,13 @@ func finder(record marlowRecord) io.Reader {
 			return
 		}
 
-		e := gosrc.WithMethod(methodName, record.store(), params, returns, func(scope url.Values) error {
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
 			logwriter := logWriter{output: gosrc, receiver: scope.Get("receiver")}
 
+			if e := writeScopedBlueprint(gosrc, record, symbols.blueprint); e != nil {
+				return e
+			}
+
 			// Prepare the array that will be returned.
 			gosrc.Println("%s := make(%s, 0)\n", symbols.results, symbols.recordSlice)
 			defer gosrc.Returns(symbols.results, writing.Nil)
@@ -89,13 +98,20 @@ func finder(record marlowRecord) io.Reader {
 				"%s := bytes.NewBufferString(\"SELECT %s FROM %s\")",
 				symbols.queryString,
 				strings.Join(columns, ","),
-				record.table(),
+				record.quote(record.table()),
 			)
 
-			// Write our where clauses
-			e := gosrc.WithIf("%s != nil", func(url.Values) error {
-				return gosrc.Println("fmt.Fprintf(%s, \" %%s\", %s)", symbols.queryString, symbols.blueprint)
-			}, symbols.blueprint)
+			// Write our join & where clauses
+			if e := writeBlueprintClauses(gosrc, record, symbols.blueprint, symbols.queryString); e != nil {
+				return e
+			}
+
+			// Write the order clause; the blueprint will validate the requested column against the known record columns.
+			e := writeOrderClause(gosrc, symbols.blueprint, symbols.queryString, symbols.order, symbols.orderError)
+
+			if e != nil {
+				return e
+			}
 
 			// Write the limit determining code.
 			limitCondition := fmt.Sprintf("%s != nil && %s.Limit >= 1", symbols.blueprint, symbols.blueprint)
@@ -123,8 +139,9 @@ func finder(record marlowRecord) io.Reader {
 
 			// Write out the limit & offset query write.
 			gosrc.Println(
-				"fmt.Fprintf(%s, \" LIMIT %%d OFFSET %%d\", %s, %s)",
+				"fmt.Fprintf(%s, %s, %s, %s)",
 				symbols.queryString,
+				strconv.Quote(record.dialect().LimitOffset()),
 				symbols.limit,
 				symbols.offset,
 			)
@@ -133,10 +150,11 @@ func finder(record marlowRecord) io.Reader {
 
 			// Write the query execution statement.
 			gosrc.Println(
-				"%s, %s := %s.Prepare(%s.String())",
+				"%s, %s := %s.PrepareContext(%s, %s.String())",
 				symbols.statementResult,
 				symbols.statementError,
 				scope.Get("receiver"),
+				ctx,
 				symbols.queryString,
 			)
 
@@ -149,10 +167,11 @@ func finder(record marlowRecord) io.Reader {
 			gosrc.Println("defer %s.Close()", symbols.statementResult)
 
 			gosrc.Println(
-				"%s, %s := %s.Query(%s.Values()...)",
+				"%s, %s := %s.QueryContext(%s, %s.Values()...)",
 				symbols.queryResult,
 				symbols.queryError,
 				symbols.statementResult,
+				ctx,
 				symbols.blueprint,
 			)
 
@@ -167,7 +186,8 @@ func finder(record marlowRecord) io.Reader {
 				references := make([]string, 0, len(record.fields))
 
 				for _, f := range fieldList {
-					references = append(references, fmt.Sprintf("&%s.%s", symbols.rowItem, f.name))
+					reference := fmt.Sprintf("&%s.%s", symbols.rowItem, f.name)
+					references = append(references, fieldValue(record, record.fields[f.name], reference))
 				}
 
 				scans := strings.Join(references, ",")
@@ -189,11 +209,6 @@ func finder(record marlowRecord) io.Reader {
 			return
 		}
 
-		record.registerStoreMethod(writing.FuncDecl{
-			Name:    methodName,
-			Params:  params,
-			Returns: returns,
-		})
 		record.registerImports("fmt", "bytes", "strings")
 
 		pw.Close()
@@ -249,7 +264,9 @@ func counter(record marlowRecord) io.Reader {
 			"error",
 		}
 
-		e := gosrc.WithMethod(symbols.countMethodName, record.store(), params, returns, func(scope url.Values) error {
+		methodName := symbols.countMethodName
+
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
 			receiver := scope.Get("receiver")
 			logwriter := logWriter{output: gosrc, receiver: receiver}
 
@@ -257,20 +274,25 @@ func counter(record marlowRecord) io.Reader {
 				return gosrc.Println("%s = &%s{}", params[0].Symbol, record.blueprint())
 			}, symbols.blueprint)
 
-			gosrc.Println(
-				"%s := fmt.Sprintf(\"SELECT COUNT(*) FROM %s %%s;\", %s)",
-				symbols.StatementQuery,
-				record.table(),
-				symbols.blueprint,
-			)
+			table := record.quote(record.table())
+			query := fmt.Sprintf("fmt.Sprintf(\"SELECT COUNT(*) FROM %s %%s;\", %s)", table, symbols.blueprint)
+
+			// The tables of related records are joined when the blueprint provides any nested relation blueprints.
+			if len(record.joins()) > 0 {
+				format := fmt.Sprintf("\"SELECT COUNT(*) FROM %s%%s %%s;\"", table)
+				query = fmt.Sprintf("fmt.Sprintf(%s, %s.joins(), %s)", format, symbols.blueprint, symbols.blueprint)
+			}
+
+			gosrc.Println("%s := %s", symbols.StatementQuery, query)
 
 			logwriter.AddLog(symbols.StatementQuery, fmt.Sprintf("%s.Values()", symbols.blueprint))
 
 			gosrc.Println(
-				"%s, %s := %s.Prepare(%s)",
+				"%s, %s := %s.PrepareContext(%s, %s)",
 				symbols.statementResult,
 				symbols.statementError,
 				receiver,
+				ctx,
 				symbols.StatementQuery,
 			)
 
@@ -282,10 +304,11 @@ func counter(record marlowRecord) io.Reader {
 
 			// Write the query execution, using the blueprint Values().
 			gosrc.Println(
-				"%s, %s := %s.Query(%s.Values()...)",
+				"%s, %s := %s.QueryContext(%s, %s.Values()...)",
 				symbols.queryResult,
 				symbols.queryError,
 				symbols.statementResult,
+				ctx,
 				symbols.blueprint,
 			)
 
@@ -312,11 +335,6 @@ func counter(record marlowRecord) io.Reader {
 
 		if e == nil {
 			record.registerImports("fmt")
-			record.registerStoreMethod(writing.FuncDecl{
-				Name:    symbols.countMethodName,
-				Params:  params,
-				Returns: returns,
-			})
 		}
 
 		pw.CloseWithError(e)
@@ -337,6 +355,8 @@ type selectorSymbols struct {
 	scanError       string
 	limit           string
 	offset          string
+	order           string
+	orderError      string
 }
 
 // selector will return a generator that will product a single field selection method for a given record store.
@@ -373,34 +393,47 @@ func selector(record marlowRecord, fieldName string, fieldConfig url.Values) io.
 		rowItem:         "_row",
 		limit:           "_limit",
 		offset:          "_offset",
+		order:           "_order",
+		orderError:      "_oe",
 	}
 
 	params := []writing.FuncParam{
 		{Type: fmt.Sprintf("*%s", record.blueprint()), Symbol: symbols.blueprint},
 	}
 
-	columnReference := fmt.Sprintf("%s.%s", record.table(), columnName)
+	columnReference := record.columnReference(columnName)
 
 	go func() {
 		gosrc := writing.NewGoWriter(pw)
 
 		gosrc.Comment("[marlow] field selector for %s (%s) [print: %s]", fieldName, methodName, record.blueprint())
 
-		e := gosrc.WithMethod(methodName, record.store(), params, returns, func(scope url.Values) error {
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
 			logwriter := logWriter{output: gosrc, receiver: scope.Get("receiver")}
+
+			if e := writeScopedBlueprint(gosrc, record, symbols.blueprint); e != nil {
+				return e
+			}
+
 			gosrc.Println("%s := make(%s, 0)", symbols.returnSlice, returnArrayType)
 
 			gosrc.Println(
 				"%s := bytes.NewBufferString(\"SELECT %s FROM %s\")",
 				symbols.queryString,
 				columnReference,
-				record.table(),
+				record.quote(record.table()),
 			)
 
-			// Write our where clauses
-			gosrc.WithIf("%s != nil", func(url.Values) error {
-				return gosrc.Println("fmt.Fprintf(%s, \" %%s\", %s)", symbols.queryString, symbols.blueprint)
-			}, symbols.blueprint)
+			// Write our join & where clauses
+			if e := writeBlueprintClauses(gosrc, record, symbols.blueprint, symbols.queryString); e != nil {
+				return e
+			}
+
+			e := writeOrderClause(gosrc, symbols.blueprint, symbols.queryString, symbols.order, symbols.orderError)
+
+			if e != nil {
+				return e
+			}
 
 			// Apply the limits and offsets to the query
 
@@ -415,17 +448,18 @@ func selector(record marlowRecord, fieldName string, fieldConfig url.Values) io.
 				return gosrc.Println("%s = %s.Limit", symbols.limit, symbols.blueprint)
 			}, symbols.blueprint, symbols.blueprint)
 
-			rangeString := "\" LIMIT %d OFFSET %d\""
+			rangeString := strconv.Quote(record.dialect().LimitOffset())
 
 			// Write the write statement for adding limit and offset into the query string.
 			gosrc.Println("fmt.Fprintf(%s, %s, %s, %s)", symbols.queryString, rangeString, symbols.limit, symbols.offset)
 
 			// Write the query execution statement.
 			gosrc.Println(
-				"%s, %s := %s.Prepare(%s.String())",
+				"%s, %s := %s.PrepareContext(%s, %s.String())",
 				symbols.statementResult,
 				symbols.statementError,
 				scope.Get("receiver"),
+				ctx,
 				symbols.queryString,
 			)
 
@@ -440,10 +474,11 @@ func selector(record marlowRecord, fieldName string, fieldConfig url.Values) io.
 
 			// Write the execution statement using the bluepring values.
 			gosrc.Println(
-				"%s, %s := %s.Query(%s.Values()...)",
+				"%s, %s := %s.QueryContext(%s, %s.Values()...)",
 				symbols.queryResult,
 				symbols.queryError,
 				symbols.statementResult,
+				ctx,
 				symbols.blueprint,
 			)
 
@@ -454,13 +489,13 @@ func selector(record marlowRecord, fieldName string, fieldConfig url.Values) io.
 			// Write out result close deferred statement.
 			gosrc.Println("defer %s.Close()", symbols.queryResult)
 
-			e := gosrc.WithIter("%s.Next()", func(url.Values) error {
+			e = gosrc.WithIter("%s.Next()", func(url.Values) error {
 				gosrc.Println("var %s %s", symbols.rowItem, returnItemType)
 				condition := fmt.Sprintf(
-					"%s := %s.Scan(&%s); %s != nil",
+					"%s := %s.Scan(%s); %s != nil",
 					symbols.scanError,
 					symbols.queryResult,
-					symbols.rowItem,
+					fieldValue(record, fieldConfig, fmt.Sprintf("&%s", symbols.rowItem)),
 					symbols.scanError,
 				)
 
@@ -475,21 +510,140 @@ func selector(record marlowRecord, fieldName string, fieldConfig url.Values) io.
 				return e
 			}
 
-			record.registerStoreMethod(writing.FuncDecl{
-				Name:    methodName,
-				Params:  params,
-				Returns: returns,
-			})
-			gosrc.Println("return %s, nil", symbols.returnSlice)
-			return nil
+			return gosrc.Returns(symbols.returnSlice, writing.Nil)
+		})
+
+		pw.CloseWithError(e)
+	}()
+
+	return pr
+}
+
+// writeOrderClause writes the code that appends the blueprint's validated ORDER BY clause into the query buffer,
+// returning early from the generated method if the blueprint requested an unknown column or direction.
+func writeOrderClause(gosrc writing.GoWriter, blueprint, buffer, order, orderError string) error {
+	gosrc.Println("%s, %s := %s.orderByString()", order, orderError, blueprint)
+
+	e := gosrc.WithIf("%s != nil", func(url.Values) error {
+		return gosrc.Returns(writing.Nil, orderError)
+	}, orderError)
+
+	if e != nil {
+		return e
+	}
+
+	return gosrc.WithIf("%s != \"\"", func(url.Values) error {
+		return gosrc.Println("fmt.Fprintf(%s, \" %%s\", %s)", buffer, order)
+	}, order)
+}
+
+// singleFinder builds a generator that creates the Find<Record> method, loading a single record by its primary key.
+func singleFinder(record marlowRecord) io.Reader {
+	pr, pw := io.Pipe()
+
+	fieldName, fieldConfig, ok := record.primaryKeyField()
+
+	// Records without a primary key column (or whose primary key is not mapped to a field) get no single finder.
+	if !ok {
+		pw.CloseWithError(nil)
+		return pr
+	}
+
+	prefix := record.config.Get(constants.StoreFindMethodPrefixConfigOption)
+	methodName := fmt.Sprintf("%s%s", prefix, record.name())
+	findName := fmt.Sprintf("%s%s", prefix, inflector.Pluralize(record.name()))
+	notFound := fmt.Sprintf("Err%sNotFound", record.name())
+
+	symbols := struct {
+		key     string
+		results string
+		error   string
+	}{"_key", "_results", "_e"}
+
+	fieldType := fieldConfig.Get("type")
+
+	params := []writing.FuncParam{
+		{Symbol: symbols.key, Type: fieldType},
+	}
+
+	returns := []string{fmt.Sprintf("*%s", record.name()), "error"}
+
+	go func() {
+		gosrc := writing.NewGoWriter(pw)
+
+		comment := "%s is returned by %s when no %s matches the provided primary key."
+		gosrc.Comment(comment, notFound, methodName, record.name())
+		gosrc.Println("var %s = errors.New(\"%s not found\")\n", notFound, strings.ToLower(record.name()))
+
+		gosrc.Comment("[marlow feature]: single finder on table[%s]", record.table())
+
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
+			blueprint := fmt.Sprintf("&%s{%s: []%s{%s}, Limit: 1}", record.blueprint(), fieldName, fieldType, symbols.key)
+			lookup := fmt.Sprintf("%s.%s(%s)", scope.Get("receiver"), findName, blueprint)
+
+			if record.contextual() {
+				lookup = fmt.Sprintf("%s.%sContext(%s, %s)", scope.Get("receiver"), findName, ctx, blueprint)
+			}
+
+			gosrc.Println("%s, %s := %s", symbols.results, symbols.error, lookup)
+
+			gosrc.WithIf("%s != nil", func(url.Values) error {
+				return gosrc.Returns(writing.Nil, symbols.error)
+			}, symbols.error)
+
+			gosrc.WithIf("len(%s) == 0", func(url.Values) error {
+				return gosrc.Returns(writing.Nil, notFound)
+			}, symbols.results)
+
+			return gosrc.Returns(fmt.Sprintf("%s[0]", symbols.results), writing.Nil)
 		})
 
+		if e == nil {
+			record.registerImports("errors")
+		}
+
 		pw.CloseWithError(e)
 	}()
 
 	return pr
 }
 
+// writeBlueprintClauses writes the code that appends the where clause of a non-nil blueprint into the query buffer,
+// preceded by the JOIN clauses of the nested relation blueprints for records that reference other records.
+func writeBlueprintClauses(gosrc writing.GoWriter, record marlowRecord, blueprint, buffer string) error {
+	return gosrc.WithIf("%s != nil", func(url.Values) error {
+		if len(record.joins()) == 0 {
+			return gosrc.Println("fmt.Fprintf(%s, \" %%s\", %s)", buffer, blueprint)
+		}
+
+		return gosrc.Println("fmt.Fprintf(%s, \"%%s %%s\", %s.joins(), %s)", buffer, blueprint, blueprint)
+	}, blueprint)
+}
+
+// writeJoinGuard writes the code that rejects blueprints filtering by related records from statements that cannot
+// join other tables (e.g. updates & deletes).
+func writeJoinGuard(gosrc writing.GoWriter, record marlowRecord, blueprint string) error {
+	if len(record.joins()) == 0 {
+		return nil
+	}
+
+	return gosrc.WithIf("%s != nil && %s.joins() != \"\"", func(url.Values) error {
+		return gosrc.Returns("-1", fmt.Sprintf("fmt.Errorf(\"%s\")", constants.InvalidJoinBlueprint))
+	}, blueprint, blueprint)
+}
+
+// writeScopedBlueprint replaces a nil blueprint with an empty one for records using soft deletion, ensuring the soft
+// delete scope of the blueprint is applied to lookups that were not given a blueprint.
+func writeScopedBlueprint(gosrc writing.GoWriter, record marlowRecord, blueprint string) error {
+	if record.softDeleteColumn() == "" {
+		return nil
+	}
+
+	return gosrc.WithIf("%s == nil", func(url.Values) error {
+		return gosrc.Println("%s = &%s{}", blueprint, record.blueprint())
+	}, blueprint)
+}
+
 // newQueryableGenerator is responsible for returning a reader that will generate lookup functions for a given record.
 func newQueryableGenerator(record marlowRecord) io.Reader {
 	pr, pw := io.Pipe()
@@ -501,7 +655,9 @@ func newQueryableGenerator(record marlowRecord) io.Reader {
 
 	features := []io.Reader{
 		finder(record),
+		singleFinder(record),
 		counter(record),
+		newRelatableGenerator(record),
 	}
 
 	for name, config := range record.fields {
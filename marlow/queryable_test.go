@@ -6,6 +6,7 @@ import "sync"
 import "bytes"
 import "net/url"
 import "testing"
+import "strings"
 import "go/ast"
 import "go/token"
 import "go/parser"
@@ -165,6 +166,57 @@ func Test_QueryableGenerator(t *testing.T) {
 				g.Assert(scaffold.received["strings"]).Equal(true)
 				g.Assert(scaffold.received["bytes"]).Equal(true)
 			})
+
+			g.Describe("with a primary key field", func() {
+				g.BeforeEach(func() {
+					scaffold.record.Set("storeFindMethodPrefix", "Find")
+					scaffold.fields["ID"] = url.Values{
+						"type":       []string{"int"},
+						"column":     []string{"id"},
+						"primaryKey": []string{"true"},
+					}
+				})
+
+				g.It("produces valid golang code", func() {
+					fmt.Fprintln(scaffold.output, "package marlowt")
+					io.Copy(scaffold.output, scaffold.g())
+					_, e := scaffold.parsed()
+					g.Assert(e).Equal(nil)
+				})
+
+				g.It("generates the single record finder and its not found error", func() {
+					io.Copy(scaffold.output, scaffold.g())
+					scaffold.close()
+					g.Assert(strings.Contains(scaffold.output.String(), "FindBook(_key int) (*Book,error)")).Equal(true)
+					g.Assert(strings.Contains(scaffold.output.String(), "ErrBookNotFound = errors.New")).Equal(true)
+					g.Assert(scaffold.received["errors"]).Equal(true)
+				})
+			})
+
+			g.Describe("with a field referencing another record", func() {
+				g.BeforeEach(func() {
+					scaffold.record.Set("storeFindMethodPrefix", "Find")
+					scaffold.fields["AuthorID"] = url.Values{
+						"type":       []string{"int"},
+						"column":     []string{"author"},
+						"references": []string{"Author.ID"},
+					}
+				})
+
+				g.It("produces valid golang code", func() {
+					fmt.Fprintln(scaffold.output, "package marlowt")
+					io.Copy(scaffold.output, scaffold.g())
+					_, e := scaffold.parsed()
+					g.Assert(e).Equal(nil)
+				})
+
+				g.It("joins the tables of the nested relation blueprints into finders, counters and selectors", func() {
+					io.Copy(scaffold.output, scaffold.g())
+					source := scaffold.output.String()
+					g.Assert(strings.Count(source, "fmt.Fprintf(_queryString, \"%s %s\", _blueprint.joins(), _blueprint)")).Equal(3)
+					g.Assert(strings.Contains(source, "\"SELECT COUNT(*) FROM books%s %s;\", _blueprint.joins()")).Equal(true)
+				})
+			})
 		})
 
 	})
@@ -6,7 +6,10 @@ import "fmt"
 import "sync"
 import "bytes"
 import "strings"
+import "net/url"
 import "path/filepath"
+import "go/ast"
+import "go/build"
 import "go/token"
 import "go/parser"
 import "go/format"
@@ -14,15 +17,32 @@ import "go/format"
 import "github.com/dadleyy/marlow/marlow/writing"
 import "github.com/dadleyy/marlow/marlow/constants"
 
-// Compile is responsible for reading from a source and writing the generated marlow code into a destination.
+// Compile is responsible for reading from a source and writing the generated marlow code into a destination. The source
+// is type checked on its own; fields using types declared elsewhere in its package are classified by their type name.
 func Compile(destination io.Writer, reader io.Reader) error {
+	return compileSource(destination, reader, "", nil)
+}
+
+// compileSource compiles the source after type checking it alongside the other files of its package.
+func compileSource(destination io.Writer, reader io.Reader, filename string, packageFiles []string) error {
 	fs := token.NewFileSet()
-	packageAst, e := parser.ParseFile(fs, "", reader, parser.AllErrors|parser.ParseComments)
+	packageAst, e := parser.ParseFile(fs, filename, reader, parser.AllErrors|parser.ParseComments)
 
 	if e != nil {
 		return e
 	}
 
+	files := []*ast.File{packageAst}
+
+	// Files of the package that cannot be parsed are left out; their declarations will be missing from the type info.
+	for _, name := range packageFiles {
+		if file, e := parser.ParseFile(fs, name, nil, parser.ParseComments); e == nil {
+			files = append(files, file)
+		}
+	}
+
+	info := checkTypes(fs, files...)
+
 	// Check to see if we are ignoring this source via the comments.
 	for _, c := range packageAst.Comments {
 		ignored := strings.Contains(c.Text(), constants.IgnoreSourceDirective)
@@ -47,9 +67,17 @@ func Compile(destination io.Writer, reader io.Reader) error {
 		packageImports[local] = cleansed
 	}
 
+	// The configs of the records declared in the package are used to resolve the records referenced by relations.
+	declared := readPackageRecords(fs, files...)
+	packageRecords := make(map[string]url.Values, len(declared))
+
+	for _, r := range declared {
+		packageRecords[r.config.Get(constants.RecordNameConfigOption)] = r.config
+	}
+
 	// Iterate over the declarations and construct the record store from the loaded ast.
 	for _, d := range packageAst.Decls {
-		reader, ok := newRecordReader(d, importChannel)
+		reader, ok := newRecordReader(d, info, packageRecords, packageEnums(declared), importChannel)
 
 		// Only deal with struct type declarations.
 		if !ok {
@@ -64,6 +92,16 @@ func Compile(destination io.Writer, reader io.Reader) error {
 		return nil
 	}
 
+	// The struct grouping the stores of the package is generated by a single file, using the records of every file.
+	if stores, ok := sharedStores(filename, declared); ok {
+		recordReaders = append(recordReaders, newStoresGenerator(stores, importChannel))
+	}
+
+	// The error wrapped by the stale error of every versioned record is also generated by a single file of the package.
+	if sharedStaleError(filename, declared) {
+		recordReaders = append(recordReaders, newStaleErrorGenerator(importChannel))
+	}
+
 	// Write out the main package information
 	packageWriter := writing.NewGoWriter(buffered)
 	packageWriter.Comment(constants.CompilerHeader)
@@ -128,7 +166,8 @@ func Compile(destination io.Writer, reader io.Reader) error {
 	return e
 }
 
-// NewReaderFromFile opens the requested filename and returns an io.Reader that represents the compiled source.
+// NewReaderFromFile opens the requested filename and returns an io.Reader that represents the compiled source. The
+// source is type checked alongside the other files of its package, allowing fields to use the types declared in them.
 func NewReaderFromFile(filename string) (io.Reader, error) {
 	source, e := os.Open(filename)
 
@@ -140,9 +179,28 @@ func NewReaderFromFile(filename string) (io.Reader, error) {
 
 	go func() {
 		defer source.Close()
-		e := Compile(pw, source)
+		e := compileSource(pw, source, filename, siblingFiles(filename))
 		pw.CloseWithError(e)
 	}()
 
 	return pr, nil
 }
+
+// siblingFiles returns the names of the other golang files in the package of the provided file.
+func siblingFiles(filename string) []string {
+	dir := filepath.Dir(filename)
+
+	// Errors are ignored; files that cannot be read (e.g. the empty destination of a file that is being generated) are
+	// reported in the error but left out of the package's list of go files.
+	pkg, _ := build.Default.ImportDir(dir, build.IgnoreVendor)
+
+	siblings := make([]string, 0, len(pkg.GoFiles))
+
+	for _, name := range pkg.GoFiles {
+		if name != filepath.Base(filename) {
+			siblings = append(siblings, filepath.Join(dir, name))
+		}
+	}
+
+	return siblings
+}
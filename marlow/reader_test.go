@@ -1,11 +1,14 @@
 package marlow
 
+import "io"
+import "os"
 import "bytes"
 import "strings"
 import "testing"
 import "go/token"
 import "go/parser"
 import "io/ioutil"
+import "path/filepath"
 import "github.com/franela/goblin"
 
 func Test_Reader(t *testing.T) {
@@ -104,6 +107,218 @@ func Test_Reader(t *testing.T) {
 			g.Assert(e).Equal(nil)
 		})
 
+		g.It("classifies fields using named types by their underlying type", func() {
+			source := strings.NewReader(`
+			package marlowt
+
+			type Construct struct {
+				table string ` + "`marlow:\"tableName=constructs\"`" + `
+				Status Status ` + "`marlow:\"column=status\"`" + `
+				Level Level ` + "`marlow:\"column=level\"`" + `
+			}
+
+			type Status string
+
+			type Level = int64
+			`)
+			e := Compile(output, source)
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "StatusLike")).Equal(true)
+			g.Assert(strings.Contains(output.String(), "LevelRange")).Equal(true)
+			g.Assert(strings.Contains(output.String(), "unsupported type")).Equal(false)
+		})
+
+		g.It("type checks the source alongside the other files of its package", func() {
+			dir, e := ioutil.TempDir("", "marlow-reader-test")
+			g.Assert(e).Equal(nil)
+			defer os.RemoveAll(dir)
+
+			construct := "package marlowt\n\ntype Construct struct {\n\tStatus Status `marlow:\"column=status\"`\n}\n"
+			status := "package marlowt\n\ntype Status string\n"
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "construct.go"), []byte(construct), 0644)).Equal(nil)
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "status.go"), []byte(status), 0644)).Equal(nil)
+
+			// The destination of the generated code is empty while the source is being compiled.
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "construct.marlow.go"), nil, 0644)).Equal(nil)
+
+			reader, e := NewReaderFromFile(filepath.Join(dir, "construct.go"))
+			g.Assert(e).Equal(nil)
+			_, e = io.Copy(output, reader)
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "StatusLike")).Equal(true)
+		})
+
+		g.It("declares the shared stores once per package, alongside the records of its first file", func() {
+			dir, e := ioutil.TempDir("", "marlow-reader-test")
+			g.Assert(e).Equal(nil)
+			defer os.RemoveAll(dir)
+
+			shared := "\ttable bool `marlow:\"sharedStores=true\"`\n"
+			author := "package marlowt\n\ntype Author struct {\n" + shared + "\tName string `marlow:\"column=name\"`\n}\n"
+			book := "package marlowt\n\ntype Book struct {\n" + shared + "\tTitle string `marlow:\"column=title\"`\n}\n"
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "author.go"), []byte(author), 0644)).Equal(nil)
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "book.go"), []byte(book), 0644)).Equal(nil)
+
+			reader, e := NewReaderFromFile(filepath.Join(dir, "author.go"))
+			g.Assert(e).Equal(nil)
+			_, e = io.Copy(output, reader)
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "type Stores struct")).Equal(true)
+			g.Assert(strings.Contains(output.String(), "Books   BookStore")).Equal(true)
+
+			output.Reset()
+			reader, e = NewReaderFromFile(filepath.Join(dir, "book.go"))
+			g.Assert(e).Equal(nil)
+			_, e = io.Copy(output, reader)
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "type Stores struct")).Equal(false)
+		})
+
+		g.It("does not declare the shared stores unless a record of the package opts into them", func() {
+			source := strings.NewReader(`
+			package marlowt
+
+			type Stores struct {
+				Authors AuthorStore
+			}
+
+			type Author struct {
+				Name string ` + "`marlow:\"column=name\"`" + `
+			}
+			`)
+			e := Compile(output, source)
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "type Stores struct")).Equal(false)
+			g.Assert(strings.Contains(output.String(), "func NewStores(")).Equal(false)
+		})
+
+		g.It("generates the code of enum types shared by the records of a package once", func() {
+			dir, e := ioutil.TempDir("", "marlow-reader-test")
+			g.Assert(e).Equal(nil)
+			defer os.RemoveAll(dir)
+
+			author := "package marlowt\n\ntype Author struct {\n\tStatus Status `marlow:\"column=status&enum=active\"`\n}\n"
+			book := "package marlowt\n\ntype Book struct {\n\tStatus Status `marlow:\"column=status&enum=active\"`\n}\n"
+			status := "package marlowt\n\ntype Status string\n"
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "author.go"), []byte(author), 0644)).Equal(nil)
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "book.go"), []byte(book), 0644)).Equal(nil)
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "status.go"), []byte(status), 0644)).Equal(nil)
+
+			reader, e := NewReaderFromFile(filepath.Join(dir, "author.go"))
+			g.Assert(e).Equal(nil)
+			_, e = io.Copy(output, reader)
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "const StatusActive Status")).Equal(true)
+			g.Assert(strings.Contains(output.String(), "func (s Status) IsValid() bool")).Equal(true)
+
+			output.Reset()
+			reader, e = NewReaderFromFile(filepath.Join(dir, "book.go"))
+			g.Assert(e).Equal(nil)
+			_, e = io.Copy(output, reader)
+			g.Assert(e).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "const StatusActive Status")).Equal(false)
+			g.Assert(strings.Contains(output.String(), "IsValid() bool")).Equal(false)
+			g.Assert(strings.Contains(output.String(), "_record.Status.IsValid()")).Equal(true)
+		})
+
+		g.It("declares the error wrapped by the stale errors of versioned records once per package", func() {
+			dir, e := ioutil.TempDir("", "marlow-reader-test")
+			g.Assert(e).Equal(nil)
+			defer os.RemoveAll(dir)
+
+			author := "package marlowt\n\ntype Author struct {\n\tName string `marlow:\"column=name\"`\n}\n"
+			book := "package marlowt\n\ntype Book struct {\n\tVersion int `marlow:\"column=version&version\"`\n}\n"
+			review := "package marlowt\n\ntype Review struct {\n\tVersion int `marlow:\"column=version&version\"`\n}\n"
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "author.go"), []byte(author), 0644)).Equal(nil)
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "book.go"), []byte(book), 0644)).Equal(nil)
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "review.go"), []byte(review), 0644)).Equal(nil)
+
+			declared := map[string]bool{"author.go": false, "book.go": true, "review.go": false}
+
+			for _, name := range []string{"author.go", "book.go", "review.go"} {
+				expected := declared[name]
+				output.Reset()
+				reader, e := NewReaderFromFile(filepath.Join(dir, name))
+				g.Assert(e).Equal(nil)
+				_, e = io.Copy(output, reader)
+				g.Assert(e).Equal(nil)
+				g.Assert(strings.Contains(output.String(), "var ErrStaleRecord = errors.New(")).Equal(expected)
+			}
+
+			g.Assert(strings.Contains(output.String(), "fmt.Errorf(\"stale review: %w\", ErrStaleRecord)")).Equal(true)
+		})
+
+		g.It("returns an error if the records of a package list different values for an enum type", func() {
+			dir, e := ioutil.TempDir("", "marlow-reader-test")
+			g.Assert(e).Equal(nil)
+			defer os.RemoveAll(dir)
+
+			author := "package marlowt\n\ntype Author struct {\n\tStatus Status `marlow:\"column=status&enum=active\"`\n}\n"
+			book := "package marlowt\n\ntype Book struct {\n\tStatus Status `marlow:\"column=status&enum=draft\"`\n}\n"
+			status := "package marlowt\n\ntype Status string\n"
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "author.go"), []byte(author), 0644)).Equal(nil)
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "book.go"), []byte(book), 0644)).Equal(nil)
+			g.Assert(ioutil.WriteFile(filepath.Join(dir, "status.go"), []byte(status), 0644)).Equal(nil)
+
+			reader, e := NewReaderFromFile(filepath.Join(dir, "book.go"))
+			g.Assert(e).Equal(nil)
+			_, e = io.Copy(output, reader)
+			g.Assert(e == nil).Equal(false)
+			g.Assert(strings.Contains(e.Error(), "Author.Status & Book.Status")).Equal(true)
+		})
+
+		g.It("returns an error if a referencing field does not share the type of the referenced field", func() {
+			source := strings.NewReader(`
+			package marlowt
+
+			type Author struct {
+				ID uint ` + "`marlow:\"column=id&primaryKey=true\"`" + `
+			}
+
+			type Book struct {
+				AuthorID int ` + "`marlow:\"column=author&references=Author.ID\"`" + `
+			}
+			`)
+			e := Compile(output, source)
+			g.Assert(e == nil).Equal(false)
+			g.Assert(strings.Contains(e.Error(), "reference AuthorID has type \"int\" but Author.ID is \"uint\"")).Equal(true)
+		})
+
+		g.It("returns an error if the referenced record does not declare the referenced field", func() {
+			source := strings.NewReader(`
+			package marlowt
+
+			type Author struct {
+				Key int ` + "`marlow:\"column=id&primaryKey=true\"`" + `
+			}
+
+			type Book struct {
+				AuthorID int ` + "`marlow:\"column=author&references=Author.ID\"`" + `
+			}
+			`)
+			e := Compile(output, source)
+			g.Assert(e == nil).Equal(false)
+			g.Assert(strings.Contains(e.Error(), "Author has no field ID")).Equal(true)
+		})
+
+		g.It("succeeds if the referencing field shares the type of the referenced field", func() {
+			source := strings.NewReader(`
+			package marlowt
+
+			type AuthorKey string
+
+			type Author struct {
+				ID AuthorKey ` + "`marlow:\"column=id&primaryKey=true\"`" + `
+			}
+
+			type Book struct {
+				AuthorID AuthorKey ` + "`marlow:\"column=author&references=Author.ID\"`" + `
+			}
+			`)
+			g.Assert(Compile(output, source)).Equal(nil)
+			g.Assert(strings.Contains(output.String(), "map[AuthorKey]*Author")).Equal(true)
+		})
+
 		g.It("returns an error if a field is mis-configured", func() {
 			source := strings.NewReader(`
 			package marlowt
@@ -12,6 +12,12 @@ type marlowRecord struct {
 	config url.Values
 	fields map[string]url.Values
 
+	// packageRecords holds the record level configuration of the records declared in the package, by record name.
+	packageRecords map[string]url.Values
+
+	// packageEnums holds the field generating the code of each enum type used by the record, by type name.
+	packageEnums map[string]packageEnum
+
 	importChannel  chan<- string
 	importRegistry map[string]bool
 
@@ -22,7 +28,7 @@ func (r *marlowRecord) fieldList(filter func(url.Values) bool) fieldList {
 	list := make(fieldList, 0, len(r.fields))
 
 	for name, c := range r.fields {
-		column := fmt.Sprintf("%s.%s", r.table(), c.Get(constants.ColumnConfigOption))
+		column := r.columnReference(c.Get(constants.ColumnConfigOption))
 
 		if filter != nil && filter(c) != true {
 			continue
@@ -36,6 +42,53 @@ func (r *marlowRecord) fieldList(filter func(url.Values) bool) fieldList {
 	return list
 }
 
+// timestampFields returns the fields of the record that have been flagged with any of the provided timestamp flags.
+func (r *marlowRecord) timestampFields(flags ...string) fieldList {
+	return r.fieldList(func(config url.Values) bool {
+		for _, flag := range flags {
+			if _, ok := config[flag]; ok {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// enumFields returns the fields of the record that are restricted to a fixed set of values using the `enum` option.
+func (r *marlowRecord) enumFields() fieldList {
+	return r.fieldList(enumField)
+}
+
+// relations returns the relations declared by the fields of the record that reference another record.
+func (r *marlowRecord) relations() []relation {
+	fields := r.fieldList(func(config url.Values) bool {
+		return config.Get(constants.ColumnReferencesOption) != ""
+	})
+
+	result := make([]relation, 0, len(fields))
+
+	for _, f := range fields {
+		result = append(result, newRelation(f.name, r.fields[f.name], r.packageRecords))
+	}
+
+	return result
+}
+
+// joins returns the relations whose referenced record can be joined into the lookups of the record. References to the
+// record itself are excluded; both sides of the join would share the same table name.
+func (r *marlowRecord) joins() []relation {
+	result := make([]relation, 0, len(r.fields))
+
+	for _, rel := range r.relations() {
+		if rel.record() != r.name() {
+			result = append(result, rel)
+		}
+	}
+
+	return result
+}
+
 func (r *marlowRecord) registerStoreMethod(method writing.FuncDecl) {
 	r.storeChannel <- method
 }
@@ -60,6 +113,39 @@ func (r *marlowRecord) registerImports(imports ...string) {
 	}
 }
 
+// primaryKeyField returns the name & config of the field whose column is the record's primary key, if any.
+func (r *marlowRecord) primaryKeyField() (string, url.Values, bool) {
+	column := r.primaryKeyColumn()
+
+	if column == "" {
+		return "", nil, false
+	}
+
+	for name, config := range r.fields {
+		if config.Get(constants.ColumnConfigOption) == column {
+			return name, config, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// versionField returns the name & config of the field flagged as the record's optimistic locking version, if any.
+func (r *marlowRecord) versionField() (string, url.Values, bool) {
+	for name, config := range r.fields {
+		if _, ok := config[constants.ColumnVersionFlag]; ok {
+			return name, config, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// staleError returns the name of the error returned by the update methods of versioned records when no rows matched.
+func (r *marlowRecord) staleError() string {
+	return fmt.Sprintf("ErrStale%s", r.name())
+}
+
 func (r *marlowRecord) primaryKeyColumn() string {
 	if r == nil {
 		return ""
@@ -86,8 +172,24 @@ func (r *marlowRecord) name() string {
 	return r.config.Get(constants.RecordNameConfigOption)
 }
 
-func (r *marlowRecord) dialect() string {
-	return r.config.Get(constants.DialectConfigOption)
+// dialect returns the Dialect registered for the record's `dialect` config option. Unknown dialect names are rejected
+// by the record reader; the default sql dialect is only returned here for records that were never validated.
+func (r *marlowRecord) dialect() Dialect {
+	if d, e := lookupDialect(r.config.Get(constants.DialectConfigOption)); e == nil {
+		return d
+	}
+
+	return dialects[""]
+}
+
+// softDeleteColumn returns the column used to flag records as deleted, if the record has soft deletion enabled.
+func (r *marlowRecord) softDeleteColumn() string {
+	return r.config.Get(constants.SoftDeleteConfigOption)
+}
+
+// contextual returns true unless the record has explicitly disabled the context-aware store method variants.
+func (r *marlowRecord) contextual() bool {
+	return r.config.Get(constants.ContextMethodsConfigOption) != "false"
 }
 
 func (r *marlowRecord) store() string {
@@ -104,6 +206,16 @@ func (r *marlowRecord) table() string {
 	return r.config.Get(constants.TableNameConfigOption)
 }
 
+// quote wraps the sql identifier (e.g. a table or column name) in the quote characters used by the record's dialect.
+func (r *marlowRecord) quote(identifier string) string {
+	return r.dialect().QuoteIdentifier(identifier)
+}
+
+// columnReference returns the table-qualified, quoted reference to the column.
+func (r *marlowRecord) columnReference(column string) string {
+	return fmt.Sprintf("%s.%s", r.quote(r.table()), r.quote(column))
+}
+
 func (r *marlowRecord) blueprint() string {
 	return r.config.Get(constants.BlueprintNameConfigOption)
 }
@@ -5,6 +5,7 @@ import "fmt"
 import "bytes"
 import "sync"
 import "go/ast"
+import "go/types"
 import "regexp"
 import "reflect"
 import "net/url"
@@ -20,6 +21,8 @@ const (
 
 var nameValidationRegex = regexp.MustCompile("^[A-z_]+$")
 
+var referenceValidationRegex = regexp.MustCompile("^[A-Z][A-Za-z0-9_]*\\.[A-Z][A-Za-z0-9_]*$")
+
 func newRecordConfig(typeName string) url.Values {
 	config := make(url.Values)
 	config.Set(constants.RecordNameConfigOption, typeName)
@@ -32,7 +35,21 @@ func newRecordConfig(typeName string) url.Values {
 
 	config.Set(constants.BlueprintNameConfigOption, blueprintName)
 	config.Set(constants.BlueprintRangeFieldSuffixConfigOption, "Range")
+	config.Set(constants.BlueprintGtFieldSuffixConfigOption, "Gt")
+	config.Set(constants.BlueprintGteFieldSuffixConfigOption, "Gte")
+	config.Set(constants.BlueprintLtFieldSuffixConfigOption, "Lt")
+	config.Set(constants.BlueprintLteFieldSuffixConfigOption, "Lte")
+	config.Set(constants.BlueprintNotFieldSuffixConfigOption, "Not")
 	config.Set(constants.BlueprintLikeFieldSuffixConfigOption, "Like")
+	config.Set(constants.BlueprintILikeFieldSuffixConfigOption, "ILike")
+	config.Set(constants.BlueprintPrefixFieldSuffixConfigOption, "Prefix")
+	config.Set(constants.BlueprintContainsFieldSuffixConfigOption, "Contains")
+	config.Set(constants.BlueprintSuffixFieldSuffixConfigOption, "Suffix")
+	config.Set(constants.BlueprintNotNullFieldSuffixConfigOption, "NotNull")
+	config.Set(constants.BlueprintJSONContainsFieldSuffixConfigOption, "Contains")
+	config.Set(constants.BlueprintJSONKeyFieldSuffixConfigOption, "Key")
+	config.Set(constants.BlueprintArrayContainsFieldSuffixConfigOption, "Contains")
+	config.Set(constants.BlueprintArrayOverlapsFieldSuffixConfigOption, "Overlaps")
 
 	config.Set(constants.StoreFindMethodPrefixConfigOption, "Find")
 	config.Set(constants.StoreCountMethodPrefixConfigOption, "Count")
@@ -41,23 +58,34 @@ func newRecordConfig(typeName string) url.Values {
 	return config
 }
 
-func parseFieldType(config *url.Values, f *ast.Field) error {
+func parseFieldType(config *url.Values, f *ast.Field, info *types.Info) error {
 	if f == nil || f.Names == nil || len(f.Names) != 1 {
 		return fmt.Errorf("invalid field: %v", f)
 	}
 
 	name := f.Names[0]
 
+	expr, pointer := f.Type, ""
+
+	// Pointer fields map nullable columns; the rest of the field's type information is read from the type pointed to.
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr, pointer = star.X, "*"
+	}
+
 	// Convert our field's type to it's string counterpart.
-	fieldType := fmt.Sprintf("%v", f.Type)
+	fieldType := types.ExprString(expr)
 
-	// Error on slice types
-	if _, ok := f.Type.(*ast.ArrayType); ok == true {
+	// Slices map the array columns of the dialects supporting them; fixed size arrays & nullable slices are not supported.
+	if array, ok := expr.(*ast.ArrayType); ok == true && (array.Len != nil || pointer != "") {
 		return fmt.Errorf("slice types not supported by marlow, field: %s", name)
 	}
 
+	if _, ok := expr.(*ast.StarExpr); ok == true {
+		return fmt.Errorf("pointers to pointer types not supported by marlow, field: %s", name)
+	}
+
 	// Check to see if this field is a complex type - one that refers to an exported type from another package.
-	selector, ok := f.Type.(*ast.SelectorExpr)
+	selector, ok := expr.(*ast.SelectorExpr)
 
 	// If the field is a complex type, make an note of the import that it is referring to - this will be mapped to the
 	// original import path from the source package by our import processor.
@@ -66,7 +94,17 @@ func parseFieldType(config *url.Values, f *ast.Field) error {
 		config.Set("import", fmt.Sprintf("%s", selector.X))
 	}
 
-	config.Set("type", fieldType)
+	// With type information, named types & aliases are classified by their underlying types (e.g. `type Status string`).
+	if underlying := underlyingTypeName(info, expr); underlying != "" {
+		config.Set("underlying", underlying)
+	}
+
+	// Fields flagged with `enum` (without listing any values) are restricted to the constants declared for their type.
+	if values, enum := (*config)[constants.ColumnEnumOption]; enum && strings.Join(values, "") == "" {
+		(*config)[enumConstantsConfigKey] = typeConstants(info, expr)
+	}
+
+	config.Set("type", pointer+fieldType)
 	return nil
 }
 
@@ -113,16 +151,49 @@ func parseStruct(d ast.Decl) (*ast.StructType, string, bool) {
 	return structType, typeName, true
 }
 
-func newRecordReader(root ast.Decl, imports chan<- string) (io.Reader, bool) {
+// parseRecordConfig returns the record level configuration of the struct, read from its special table (or blank) field.
+func parseRecordConfig(structType *ast.StructType, typeName string) url.Values {
+	recordConfig := newRecordConfig(typeName)
+
+	for _, f := range structType.Fields.List {
+		name, fieldConfig, ok := parseField(f)
+
+		if !ok || (name != "table" && name != "_") {
+			continue
+		}
+
+		for k := range fieldConfig {
+			recordConfig.Set(k, fieldConfig.Get(k))
+		}
+	}
+
+	return recordConfig
+}
+
+// newRecordReader returns a reader generating the store & blueprint of the record declared by the struct. The configs
+// of the package records are used to resolve the records referenced by its fields, while the package enums determine
+// the enum types whose code is generated alongside the record.
+func newRecordReader(
+	root ast.Decl,
+	info *types.Info,
+	packageRecords map[string]url.Values,
+	packageEnums map[string]packageEnum,
+	imports chan<- string,
+) (io.Reader, bool) {
 	structType, typeName, ok := parseStruct(root)
 
 	if !ok {
 		return nil, false
 	}
 
-	recordConfig, recordFields := newRecordConfig(typeName), make(map[string]url.Values)
+	recordConfig, recordFields := parseRecordConfig(structType, typeName), make(map[string]url.Values)
 
 	columnMap := make(map[string]string)
+	versionField, enumTypes := "", make(map[string]packageEnum, len(packageEnums))
+
+	for typeName, enum := range packageEnums {
+		enumTypes[typeName] = enum
+	}
 
 	pr, pw := io.Pipe()
 
@@ -134,11 +205,6 @@ func newRecordReader(root ast.Decl, imports chan<- string) (io.Reader, bool) {
 		}
 
 		if name == "table" || name == "_" {
-			for k := range fieldConfig {
-				v := fieldConfig.Get(k)
-				recordConfig.Set(k, v)
-			}
-
 			continue
 		}
 
@@ -167,11 +233,127 @@ func newRecordReader(root ast.Decl, imports chan<- string) (io.Reader, bool) {
 			return pr, true
 		}
 
-		if e := parseFieldType(&fieldConfig, f); e != nil {
+		if e := parseFieldType(&fieldConfig, f, info); e != nil {
 			pw.CloseWithError(e)
 			return pr, true
 		}
 
+		if kind := fieldConfig.Get(constants.ColumnKindOption); kind != "" {
+			if _, ok := columnKinds[kind]; !ok {
+				kinds := strings.Join([]string{
+					constants.NumericColumnKind,
+					constants.OpaqueColumnKind,
+					constants.StringColumnKind,
+				}, ", ")
+
+				pw.CloseWithError(fmt.Errorf("invalid kind for %s: %s (expected one of: %s)", name, kind, kinds))
+				return pr, true
+			}
+		}
+
+		// Json fields are marshaled into documents; the values of basic & nullable types are stored as they are.
+		if fieldType := fieldConfig.Get("type"); jsonField(fieldConfig) {
+			if _, sqlNull := sqlNullField(fieldConfig); sqlNull || fieldTypeInfo(fieldConfig) != 0 {
+				e := fmt.Errorf("json columns must be structs, maps or json.RawMessage, %s has type \"%s\"", name, fieldType)
+				pw.CloseWithError(e)
+				return pr, true
+			}
+		}
+
+		_, createdAt := fieldConfig[constants.ColumnCreatedAtFlag]
+		_, updatedAt := fieldConfig[constants.ColumnUpdatedAtFlag]
+
+		if fieldType := fieldConfig.Get("type"); (createdAt || updatedAt) && fieldType != "time.Time" {
+			pw.CloseWithError(fmt.Errorf("timestamp columns must be time.Time, %s has type \"%s\"", name, fieldType))
+			return pr, true
+		}
+
+		if _, version := fieldConfig[constants.ColumnVersionFlag]; version {
+			fieldType := fieldConfig.Get("type")
+
+			// The version column is incremented by the update statements; a NULL version would never be incremented.
+			if fieldTypeInfo(fieldConfig)&types.IsInteger == 0 || nullableField(fieldConfig) {
+				pw.CloseWithError(fmt.Errorf("version columns must be integers, %s has type \"%s\"", name, fieldType))
+				return pr, true
+			}
+
+			if versionField != "" {
+				pw.CloseWithError(fmt.Errorf("duplicate version fields: %s & %s", versionField, name))
+				return pr, true
+			}
+
+			versionField = name
+		}
+
+		if reference := fieldConfig.Get(constants.ColumnReferencesOption); reference != "" {
+			if !referenceValidationRegex.MatchString(reference) {
+				pw.CloseWithError(fmt.Errorf("invalid reference for %s: %s (expected Record.Field)", name, reference))
+				return pr, true
+			}
+
+			fieldType := fieldConfig.Get("type")
+
+			if fieldTypeInfo(fieldConfig)&(types.IsInteger|types.IsString) == 0 {
+				pw.CloseWithError(fmt.Errorf("reference columns must be integers or strings, %s has type \"%s\"", name, fieldType))
+				return pr, true
+			}
+
+			// The referenced records are keyed by the value of the field; nullable values would never match.
+			if nullableField(fieldConfig) {
+				pw.CloseWithError(fmt.Errorf("reference columns must not be nullable, %s has type \"%s\"", name, fieldType))
+				return pr, true
+			}
+
+			// The values of the field are used to look up the referenced records by the referenced field.
+			rel := newRelation(name, fieldConfig, packageRecords)
+
+			keyType, declared := rel.keyType()
+
+			if !declared {
+				pw.CloseWithError(fmt.Errorf("invalid reference for %s: %s has no field %s", name, rel.record(), rel.key))
+				return pr, true
+			}
+
+			if keyType != "" && keyType != fieldType {
+				e := fmt.Errorf("reference %s has type \"%s\" but %s is \"%s\"", name, fieldType, reference, keyType)
+				pw.CloseWithError(e)
+				return pr, true
+			}
+		}
+
+		if enumField(fieldConfig) {
+			if e := parseEnum(name, fieldConfig); e != nil {
+				pw.CloseWithError(e)
+				return pr, true
+			}
+
+			// The constants & IsValid method are generated once for each enum type; every field of the type shares them.
+			enum := packageEnum{
+				typeName: fieldValueType(fieldConfig),
+				record:   typeName,
+				field:    name,
+				values:   fieldConfig.Get(constants.ColumnEnumOption),
+			}
+
+			if owner, shared := enumTypes[enum.typeName]; shared && owner.values != enum.values {
+				e := fmt.Errorf(
+					"enum fields of type %s must list the same values: %s.%s & %s.%s",
+					enum.typeName,
+					owner.record,
+					owner.field,
+					enum.record,
+					enum.field,
+				)
+
+				pw.CloseWithError(e)
+				return pr, true
+			}
+
+			if _, shared := enumTypes[enum.typeName]; !shared {
+				enumTypes[enum.typeName] = enum
+			}
+		}
+
 		recordFields[name] = fieldConfig
 	}
 
@@ -180,12 +362,41 @@ func newRecordReader(root ast.Decl, imports chan<- string) (io.Reader, bool) {
 		return pr, true
 	}
 
+	softDelete := recordConfig.Get(constants.SoftDeleteConfigOption)
+
+	if softDelete != "" && !nameValidationRegex.MatchString(softDelete) {
+		pw.CloseWithError(fmt.Errorf("invalid soft delete column name: %s", softDelete))
+		return pr, true
+	}
+
+	keyed := marlowRecord{config: recordConfig, fields: recordFields}
+
+	if name, config, ok := keyed.primaryKeyField(); ok && nullableField(config) {
+		fieldType := config.Get("type")
+		pw.CloseWithError(fmt.Errorf("primary key columns must not be nullable, %s has type \"%s\"", name, fieldType))
+		return pr, true
+	}
+
+	if _, e := lookupDialect(recordConfig.Get(constants.DialectConfigOption)); e != nil {
+		pw.CloseWithError(e)
+		return pr, true
+	}
+
+	for _, f := range keyed.fieldList(arrayField) {
+		if e := parseArray(keyed, f.name, recordFields[f.name]); e != nil {
+			pw.CloseWithError(e)
+			return pr, true
+		}
+	}
+
 	go func() {
 		record := marlowRecord{
-			config:        recordConfig,
-			fields:        recordFields,
-			importChannel: imports,
-			storeChannel:  make(chan writing.FuncDecl),
+			config:         recordConfig,
+			fields:         recordFields,
+			packageRecords: packageRecords,
+			packageEnums:   enumTypes,
+			importChannel:  imports,
+			storeChannel:   make(chan writing.FuncDecl),
 		}
 
 		e := readRecord(pw, record)
@@ -195,19 +406,31 @@ func newRecordReader(root ast.Decl, imports chan<- string) (io.Reader, bool) {
 	return pr, true
 }
 
+// storeFeatures are the generators of the features that are enabled unless explicitly disabled in the record config.
+var storeFeatures = map[string]func(marlowRecord) io.Reader{
+	constants.CreateableConfigOption: newCreateableGenerator,
+	constants.UpdateableConfigOption: newUpdateableGenerator,
+	constants.DeleteableConfigOption: newDeleteableGenerator,
+	constants.QueryableConfigOption:  newQueryableGenerator,
+}
+
+// generatesStore returns true if any of the features generating the store of a record are enabled in its config.
+func generatesStore(config url.Values) bool {
+	for flag := range storeFeatures {
+		if config.Get(flag) != "false" {
+			return true
+		}
+	}
+
+	return config.Get(constants.UpsertableConfigOption) == "true"
+}
+
 func readRecord(writer io.Writer, record marlowRecord) error {
 	buffer := new(bytes.Buffer)
 
 	readers := make([]io.Reader, 0, 4)
 
-	features := map[string]func(marlowRecord) io.Reader{
-		constants.CreateableConfigOption: newCreateableGenerator,
-		constants.UpdateableConfigOption: newUpdateableGenerator,
-		constants.DeleteableConfigOption: newDeleteableGenerator,
-		constants.QueryableConfigOption:  newQueryableGenerator,
-	}
-
-	for flag, generator := range features {
+	for flag, generator := range storeFeatures {
 		v := record.config.Get(flag)
 
 		if v == "false" {
@@ -218,6 +441,11 @@ func readRecord(writer io.Writer, record marlowRecord) error {
 		readers = append(readers, g)
 	}
 
+	// Upserts depend on unique constraints in the schema, so they are only generated when explicitly enabled.
+	if record.config.Get(constants.UpsertableConfigOption) == "true" {
+		readers = append(readers, newUpsertableGenerator(record))
+	}
+
 	if len(readers) == 0 {
 		comment := strings.NewReader(
 			fmt.Sprintf("/* [marlow no-features]: %s */\n\n", record.config.Get(constants.RecordNameConfigOption)),
@@ -228,7 +456,7 @@ func readRecord(writer io.Writer, record marlowRecord) error {
 	}
 
 	// If we had any features enabled, we need to also generate the blue print API.
-	readers = append(readers, newBlueprintGenerator(record))
+	readers = append(readers, newBlueprintGenerator(record), newEnumerableGenerator(record), newJSONGenerator(record))
 
 	methods := make(map[string]writing.FuncDecl)
 	wg := &sync.WaitGroup{}
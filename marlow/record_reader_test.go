@@ -3,7 +3,6 @@ package marlow
 import "io"
 import "sync"
 import "bytes"
-import "go/ast"
 import "testing"
 import "strings"
 import "go/token"
@@ -18,8 +17,10 @@ type recordReaderTestScaffold struct {
 	closed  bool
 }
 
-func (s *recordReaderTestScaffold) root() ast.Decl {
-	tree, e := parser.ParseFile(token.NewFileSet(), "", s.source, parser.AllErrors)
+// reader type checks the source, returning the record reader of its first declaration.
+func (s *recordReaderTestScaffold) reader() (io.Reader, bool) {
+	fs := token.NewFileSet()
+	tree, e := parser.ParseFile(fs, "", s.source, parser.AllErrors)
 
 	if e != nil {
 		panic(e)
@@ -29,7 +30,7 @@ func (s *recordReaderTestScaffold) root() ast.Decl {
 		panic("not enough declarations in provided source")
 	}
 
-	return tree.Decls[0]
+	return newRecordReader(tree.Decls[0], checkTypes(fs, tree), nil, nil, s.imports)
 }
 
 func (s *recordReaderTestScaffold) close() {
@@ -42,7 +43,7 @@ func (s *recordReaderTestScaffold) close() {
 }
 
 func (s *recordReaderTestScaffold) error() error {
-	reader, _ := newRecordReader(s.root(), s.imports)
+	reader, _ := s.reader()
 	_, e := io.Copy(s.output, reader)
 	return e
 }
@@ -91,7 +92,7 @@ func Test_RecordReader(t *testing.T) {
 				type Author struct {
 					Title string
 				}`)
-			reader, ok := newRecordReader(scaffold.root(), scaffold.imports)
+			reader, ok := scaffold.reader()
 			g.Assert(ok).Equal(true)
 			_, e := io.Copy(scaffold.output, reader)
 			scaffold.close()
@@ -104,7 +105,7 @@ func Test_RecordReader(t *testing.T) {
 					type Author struct {
 						Title string ` + "`marlow:\"column=title\"`" + `
 					}`)
-			reader, ok := newRecordReader(scaffold.root(), scaffold.imports)
+			reader, ok := scaffold.reader()
 			g.Assert(ok).Equal(true)
 			_, e := io.Copy(scaffold.output, reader)
 			scaffold.close()
@@ -118,7 +119,7 @@ func Test_RecordReader(t *testing.T) {
 						table string ` + "`marlow:\"tableName=authors\"`" + `
 						Title string
 					}`)
-			reader, ok := newRecordReader(scaffold.root(), scaffold.imports)
+			reader, ok := scaffold.reader()
 			g.Assert(ok).Equal(true)
 			_, e := io.Copy(scaffold.output, reader)
 			scaffold.close()
@@ -177,6 +178,356 @@ func Test_RecordReader(t *testing.T) {
 			g.Assert(scaffold.error() == nil).Equal(false)
 		})
 
+		g.It("encodes slice fields of postgres records as arrays", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					table       bool ` + "`marlow:\"dialect=postgres&primaryKey=id\"`" + `
+					ID          uint ` + "`marlow:\"column=id\"`" + `
+					Tags        []string ` + "`marlow:\"column=tags\"`" + `
+				}
+			`)
+			g.Assert(scaffold.error()).Equal(nil)
+			output := scaffold.output.String()
+			g.Assert(strings.Contains(output, "pq.Array(&_row.Tags)")).Equal(true)
+			g.Assert(strings.Contains(output, "TagsOverlaps []string")).Equal(true)
+		})
+
+		g.It("errors during copy if an array field has an unsupported element type", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					table       bool ` + "`marlow:\"dialect=postgres\"`" + `
+					Ratings     []int ` + "`marlow:\"column=ratings\"`" + `
+				}
+			`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if a slice field is a pointer", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					table       bool ` + "`marlow:\"dialect=postgres\"`" + `
+					Tags        *[]string ` + "`marlow:\"column=tags\"`" + `
+				}
+			`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("reads pointer fields as nullable fields of the type pointed to", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Subtitle    *string ` + "`marlow:\"column=subtitle\"`" + `
+					Rating      *Rating ` + "`marlow:\"column=rating\"`" + `
+					PublishedAt *time.Time ` + "`marlow:\"column=published_at\"`" + `
+				}
+				type Rating float64
+			`)
+			g.Assert(scaffold.error()).Equal(nil)
+			g.Assert(strings.Contains(scaffold.output.String(), "SubtitleNotNull")).Equal(true)
+			g.Assert(strings.Contains(scaffold.output.String(), "RatingRange")).Equal(true)
+			g.Assert(strings.Contains(scaffold.output.String(), "PublishedAt []*time.Time")).Equal(true)
+			g.Assert(strings.Contains(scaffold.output.String(), "unsupported type")).Equal(false)
+		})
+
+		g.It("errors during copy if pointer to pointer field type", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Subtitle **string ` + "`marlow:\"column=subtitle\"`" + `
+			}`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if a version field is a pointer", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Title 			string
+					Version     *int ` + "`marlow:\"column=version&version\"`" + `
+			}`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if a referencing field is a pointer", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Title 			string
+					AuthorID    *int ` + "`marlow:\"column=author&references=Author.ID\"`" + `
+			}`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if a referencing field is a database/sql nullable type", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Title 			string
+					AuthorID    sql.NullInt64 ` + "`marlow:\"column=author&references=Author.ID\"`" + `
+			}`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if the primary key field is a pointer", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Title 			string
+					ID          *int ` + "`marlow:\"column=id&primaryKey=true\"`" + `
+			}`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if a timestamp field is not a time.Time", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Author struct {
+					Title 			string
+					CreatedAt   string ` + "`marlow:\"column=created_at&createdAt\"`" + `
+			}`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if a version field is not an integer", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Author struct {
+					Title 			string
+					Version     string ` + "`marlow:\"column=version&version\"`" + `
+			}`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if a reference is not in the Record.Field format", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Title 			string
+					AuthorID    int ` + "`marlow:\"column=author&references=author\"`" + `
+			}`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if a referencing field is not an integer or string", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Title 			string
+					AuthorID    []int ` + "`marlow:\"column=author&references=Author.ID\"`" + `
+			}`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("classifies named version & reference fields by their underlying types", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Title 			string
+					AuthorID    AuthorKey ` + "`marlow:\"column=author&references=Author.ID\"`" + `
+					Version     Revision ` + "`marlow:\"column=version&version\"`" + `
+				}
+				type AuthorKey string
+				type Revision uint
+			`)
+			g.Assert(scaffold.error()).Equal(nil)
+		})
+
+		g.It("generates the clauses declared by the kind of custom types", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					ISBN        ISBN ` + "`marlow:\"column=isbn&kind=string\"`" + `
+					Price       Money ` + "`marlow:\"column=price&kind=numeric\"`" + `
+					Token       Token ` + "`marlow:\"column=token&kind=opaque\"`" + `
+				}
+				type ISBN struct{ digits string }
+				type Money struct{ cents int64 }
+				type Token struct{ value []byte }
+			`)
+			g.Assert(scaffold.error()).Equal(nil)
+			g.Assert(strings.Contains(scaffold.output.String(), "ISBNLike")).Equal(true)
+			g.Assert(strings.Contains(scaffold.output.String(), "PriceRange")).Equal(true)
+			g.Assert(strings.Contains(scaffold.output.String(), "tokenInString")).Equal(true)
+			g.Assert(strings.Contains(scaffold.output.String(), "TokenLike")).Equal(false)
+			g.Assert(strings.Contains(scaffold.output.String(), "unsupported type")).Equal(false)
+		})
+
+		g.It("errors during copy if the kind of a field is unknown", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Title 			string
+					ISBN        ISBN ` + "`marlow:\"column=isbn&kind=text\"`" + `
+			}`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("generates the constants & validation of the values listed by enum fields", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Status      Status ` + "`marlow:\"column=status&enum=on_hold,in-print\"`" + `
+					Priority    *Priority ` + "`marlow:\"column=priority&enum=low:1,high:2\"`" + `
+				}
+				type Status string
+				type Priority uint8
+			`)
+			g.Assert(scaffold.error()).Equal(nil)
+			output := scaffold.output.String()
+			g.Assert(strings.Contains(output, "const StatusOnHold Status = \"on_hold\"")).Equal(true)
+			g.Assert(strings.Contains(output, "const StatusInPrint Status = \"in-print\"")).Equal(true)
+			g.Assert(strings.Contains(output, "const PriorityHigh Priority = 2")).Equal(true)
+			g.Assert(strings.Contains(output, "func (s Status) IsValid() bool")).Equal(true)
+			g.Assert(strings.Contains(output, "func (p Priority) IsValid() bool")).Equal(true)
+			g.Assert(strings.Contains(output, "_updates != nil && _updates.IsValid() != true")).Equal(true)
+		})
+
+		g.It("uses the declared constants of the types of enum fields without values", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Status      Status ` + "`marlow:\"column=status&enum\"`" + `
+				}
+				type Status string
+				const StatusDraft Status = "draft"
+			`)
+			g.Assert(scaffold.error()).Equal(nil)
+			output := scaffold.output.String()
+			g.Assert(strings.Contains(output, "const StatusDraft")).Equal(false)
+			g.Assert(strings.Contains(output, "return s == StatusDraft")).Equal(true)
+		})
+
+		g.It("errors during copy if an enum field without values has no declared constants", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Status      Status ` + "`marlow:\"column=status&enum\"`" + `
+				}
+				type Status string
+			`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if an enum field is not a named type", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Status      string ` + "`marlow:\"column=status&enum=draft\"`" + `
+				}
+			`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if an enum field is not a string or integer type", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Status      Status ` + "`marlow:\"column=status&enum=draft\"`" + `
+				}
+				type Status float64
+			`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if an enum value is not valid", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Status      Status ` + "`marlow:\"column=status&enum=draft,_hidden\"`" + `
+				}
+				type Status string
+			`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if enum values share a constant name", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Status      Status ` + "`marlow:\"column=status&enum=on_hold,on-hold\"`" + `
+				}
+				type Status string
+			`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("generates the code of enum types shared by fields once", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Status      Status ` + "`marlow:\"column=status&enum=draft\"`" + `
+					Previous    *Status ` + "`marlow:\"column=previous&enum=draft\"`" + `
+				}
+				type Status string
+			`)
+			g.Assert(scaffold.error()).Equal(nil)
+			output := scaffold.output.String()
+			g.Assert(strings.Count(output, "const StatusDraft Status")).Equal(1)
+			g.Assert(strings.Count(output, "func (s Status) IsValid() bool")).Equal(1)
+		})
+
+		g.It("errors during copy if enum fields sharing a type list different values", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Status      Status ` + "`marlow:\"column=status&enum=draft\"`" + `
+					Previous    *Status ` + "`marlow:\"column=previous&enum=draft,published\"`" + `
+				}
+				type Status string
+			`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if an integer enum value is listed without its value", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Book struct {
+					Priority    Priority ` + "`marlow:\"column=priority&enum=low,high\"`" + `
+				}
+				type Priority uint8
+			`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("wraps the values of json fields using the json value type of the record", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Genre struct {
+					table       bool ` + "`marlow:\"dialect=postgres&primaryKey=id\"`" + `
+					ID          uint ` + "`marlow:\"column=id\"`" + `
+					References  map[string]string ` + "`marlow:\"column=refs&json\"`" + `
+				}
+			`)
+			g.Assert(scaffold.error()).Equal(nil)
+			output := scaffold.output.String()
+			g.Assert(strings.Contains(output, "type genreJSON struct")).Equal(true)
+			g.Assert(strings.Contains(output, "ReferencesContains []map[string]string")).Equal(true)
+			g.Assert(strings.Contains(output, "genreJSON{&_row.References}")).Equal(true)
+		})
+
+		g.It("errors during copy if a json field is a basic type", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Genre struct {
+					References  string ` + "`marlow:\"column=refs&json\"`" + `
+				}
+			`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
+		g.It("errors during copy if the dialect is unknown", func() {
+			scaffold.source = strings.NewReader(`
+				package marlowt
+				type Author struct {
+					table bool ` + "`marlow:\"dialect=oracle\"`" + `
+					Title string
+			}`)
+			g.Assert(scaffold.error() == nil).Equal(false)
+		})
+
 		g.It("does not produce anything if all features are disabled", func() {
 			scaffold.source = strings.NewReader(`
 			package main
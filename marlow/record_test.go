@@ -1,8 +1,10 @@
 package marlow
 
 import "sync"
+import "net/url"
 import "testing"
 import "github.com/franela/goblin"
+import "github.com/dadleyy/marlow/marlow/constants"
 
 func Test_Record(t *testing.T) {
 	g := goblin.Goblin(t)
@@ -42,5 +44,22 @@ func Test_Record(t *testing.T) {
 			wg.Wait()
 			g.Assert(received["fmt"]).Equal(1)
 		})
+
+		g.It("does not quote identifiers for the default dialect", func() {
+			record.config = make(url.Values)
+			record.config.Set(constants.TableNameConfigOption, "books")
+			g.Assert(record.columnReference("title")).Equal("books.title")
+			close(imports)
+			wg.Wait()
+		})
+
+		g.It("quotes identifiers with backticks for the mysql dialect", func() {
+			record.config = make(url.Values)
+			record.config.Set(constants.TableNameConfigOption, "books")
+			record.config.Set(constants.DialectConfigOption, "mysql")
+			g.Assert(record.columnReference("title")).Equal("`books`.`title`")
+			close(imports)
+			wg.Wait()
+		})
 	})
 }
@@ -0,0 +1,300 @@
+package marlow
+
+import "io"
+import "fmt"
+import "strings"
+import "net/url"
+import "go/ast"
+import "go/types"
+import "github.com/gedex/inflector"
+import "github.com/dadleyy/marlow/marlow/writing"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+// relation holds the names used when generating the lookups between a record and the record referenced by one of its
+// fields (e.g. `references=Author.ID`). Records that are not declared in the package sources being compiled are
+// assumed to use the default record configuration.
+type relation struct {
+	field     string
+	fieldType string
+	column    string
+
+	name      string
+	reference url.Values
+	key       string
+}
+
+// fieldTypesConfigKey is the record config key holding the `Field:type` pairs of the fields of the records declared in
+// the package, used to check that the fields referencing a record share the type of the referenced field.
+const fieldTypesConfigKey = "fieldTypes"
+
+type relationSymbols struct {
+	records string
+	results string
+	seen    string
+	keys    string
+	item    string
+	store   string
+	related string
+	e       string
+}
+
+func newRelation(fieldName string, fieldConfig url.Values, packageRecords map[string]url.Values) relation {
+	parts := strings.SplitN(fieldConfig.Get(constants.ColumnReferencesOption), ".", 2)
+	name := strings.TrimSuffix(fieldName, parts[1])
+	reference, ok := packageRecords[parts[0]]
+
+	if !ok {
+		reference = newRecordConfig(parts[0])
+	}
+
+	// Fields named after the referenced field (e.g. `ID`) fall back to the name of the referenced record.
+	if name == "" {
+		name = parts[0]
+	}
+
+	return relation{
+		field:     fieldName,
+		fieldType: fieldConfig.Get("type"),
+		column:    fieldConfig.Get(constants.ColumnConfigOption),
+		name:      name,
+		reference: reference,
+		key:       parts[1],
+	}
+}
+
+// parseFieldTypes returns the `Field:type` pairs of the named fields declared by the struct.
+func parseFieldTypes(structType *ast.StructType) []string {
+	pairs := make([]string, 0, len(structType.Fields.List))
+
+	for _, f := range structType.Fields.List {
+		for _, name := range f.Names {
+			pairs = append(pairs, fmt.Sprintf("%s:%s", name.Name, types.ExprString(f.Type)))
+		}
+	}
+
+	return pairs
+}
+
+// keyType returns the type of the referenced field, provided the referenced record is declared in the package. The
+// second return value is false if the referenced record is declared in the package without the referenced field.
+func (r relation) keyType() (string, bool) {
+	pairs, declared := r.reference[fieldTypesConfigKey]
+
+	if !declared {
+		return "", true
+	}
+
+	for _, pair := range pairs {
+		if parts := strings.SplitN(pair, ":", 2); parts[0] == r.key {
+			return parts[1], true
+		}
+	}
+
+	return "", false
+}
+
+func (r relation) record() string {
+	return r.reference.Get(constants.RecordNameConfigOption)
+}
+
+func (r relation) blueprint() string {
+	return r.reference.Get(constants.BlueprintNameConfigOption)
+}
+
+func (r relation) store() string {
+	return r.reference.Get(constants.StoreNameConfigOption)
+}
+
+// contextual returns true unless the referenced record has disabled the context-aware variants of its store methods.
+func (r relation) contextual() bool {
+	return r.reference.Get(constants.ContextMethodsConfigOption) != "false"
+}
+
+func newRelationSymbols() relationSymbols {
+	return relationSymbols{
+		records: "_records",
+		results: "_results",
+		seen:    "_seen",
+		keys:    "_keys",
+		item:    "_item",
+		store:   "_store",
+		related: "_related",
+		e:       "_e",
+	}
+}
+
+// writeRelationKeys writes the loop collecting the distinct, non-nil key values of the records in the records param.
+func writeRelationKeys(gosrc writing.GoWriter, symbols relationSymbols, keyType, keyField string) {
+	gosrc.Println("%s := make(map[%s]bool, len(%s))", symbols.seen, keyType, symbols.records)
+	gosrc.Println("%s := make([]%s, 0, len(%s))", symbols.keys, keyType, symbols.records)
+
+	gosrc.WithIter("_, %s := range %s", func(url.Values) error {
+		gosrc.WithIf("%s == nil || %s[%s.%s]", func(url.Values) error {
+			return gosrc.Println("continue")
+		}, symbols.item, symbols.seen, symbols.item, keyField)
+
+		gosrc.Println("%s[%s.%s] = true", symbols.seen, symbols.item, keyField)
+		return gosrc.Println("%s = append(%s, %s.%s)", symbols.keys, symbols.keys, symbols.item, keyField)
+	}, symbols.item, symbols.records)
+
+	gosrc.WithIf("len(%s) == 0", func(url.Values) error {
+		return gosrc.Returns(symbols.results, writing.Nil)
+	}, symbols.keys)
+}
+
+// belongsTo returns a reader that generates the lookup of the records referenced by a field of the record, e.g. the
+// `FindBookAuthors(books []*Book) (map[int]*Author, error)` method. The referenced records are loaded using a single
+// query against the store of the referenced record.
+func belongsTo(record marlowRecord, rel relation) io.Reader {
+	pr, pw := io.Pipe()
+	symbols := newRelationSymbols()
+	prefix := record.config.Get(constants.StoreFindMethodPrefixConfigOption)
+	methodName := fmt.Sprintf("%s%s%s", prefix, record.name(), inflector.Pluralize(rel.name))
+	resultType := fmt.Sprintf("map[%s]*%s", rel.fieldType, rel.record())
+
+	params := []writing.FuncParam{
+		{Symbol: symbols.records, Type: fmt.Sprintf("[]*%s", record.name())},
+	}
+
+	returns := []string{resultType, "error"}
+
+	go func() {
+		gosrc := writing.NewGoWriter(pw)
+		gosrc.Comment("[marlow] belongs-to relation %s.%s -> %s.%s", record.name(), rel.field, rel.record(), rel.key)
+
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
+			receiver := scope.Get("receiver")
+
+			gosrc.Println("%s := make(%s)", symbols.results, resultType)
+			writeRelationKeys(gosrc, symbols, rel.fieldType, rel.field)
+
+			// Use a store for the referenced record that shares the connection (and transaction) of this store.
+			gosrc.Println(
+				"%s := New%s(%s.%s, %s.%s)",
+				symbols.store,
+				rel.store(),
+				receiver,
+				constants.StoreDatabaseField,
+				receiver,
+				constants.StoreLoggerField,
+			)
+
+			gosrc.WithIf("%s.%s != nil", func(url.Values) error {
+				tx := fmt.Sprintf("%s.%s", receiver, constants.StoreTransactionField)
+				return gosrc.Println("%s = %s.WithTx(%s)", symbols.store, symbols.store, tx)
+			}, receiver, constants.StoreTransactionField)
+
+			blueprint := fmt.Sprintf("&%s{%s: %s, Limit: len(%s)}", rel.blueprint(), rel.key, symbols.keys, symbols.keys)
+
+			finder := fmt.Sprintf(
+				"%s%s",
+				rel.reference.Get(constants.StoreFindMethodPrefixConfigOption),
+				inflector.Pluralize(rel.record()),
+			)
+
+			lookup := fmt.Sprintf("%s.%s(%s)", symbols.store, finder, blueprint)
+
+			// The context is only forwarded when the store of the referenced record has context-aware finders.
+			if rel.contextual() {
+				lookup = fmt.Sprintf("%s.%sContext(%s, %s)", symbols.store, finder, ctx, blueprint)
+			}
+
+			gosrc.Println("%s, %s := %s", symbols.related, symbols.e, lookup)
+
+			gosrc.WithIf("%s != nil", func(url.Values) error {
+				return gosrc.Returns(writing.Nil, symbols.e)
+			}, symbols.e)
+
+			gosrc.WithIter("_, %s := range %s", func(url.Values) error {
+				return gosrc.Println("%s[%s.%s] = %s", symbols.results, symbols.item, rel.key, symbols.item)
+			}, symbols.item, symbols.related)
+
+			return gosrc.Returns(symbols.results, writing.Nil)
+		})
+
+		pw.CloseWithError(e)
+	}()
+
+	return pr
+}
+
+// hasMany returns a reader that generates the reverse lookup of a field reference, loading the records that refer to
+// each of the referenced records, e.g. the `FindAuthorBooks(authors []*Author) (map[int][]*Book, error)` method. The
+// lookup is generated on the store of the referencing record and uses a single query that is not limited by the
+// default blueprint limit.
+func hasMany(record marlowRecord, rel relation) io.Reader {
+	pr, pw := io.Pipe()
+	symbols := newRelationSymbols()
+	prefix := record.config.Get(constants.StoreFindMethodPrefixConfigOption)
+	methodName := fmt.Sprintf("%s%s%s", prefix, rel.name, inflector.Pluralize(record.name()))
+	resultType := fmt.Sprintf("map[%s][]*%s", rel.fieldType, record.name())
+
+	params := []writing.FuncParam{
+		{Symbol: symbols.records, Type: fmt.Sprintf("[]*%s", rel.record())},
+	}
+
+	returns := []string{resultType, "error"}
+
+	go func() {
+		gosrc := writing.NewGoWriter(pw)
+		gosrc.Comment("[marlow] has-many relation %s.%s <- %s.%s", rel.record(), rel.key, record.name(), rel.field)
+
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
+			receiver := scope.Get("receiver")
+
+			gosrc.Println("%s := make(%s)", symbols.results, resultType)
+			writeRelationKeys(gosrc, symbols, rel.fieldType, rel.key)
+
+			blueprint := fmt.Sprintf("&%s{%s: %s, Limit: math.MaxInt32}", record.blueprint(), rel.field, symbols.keys)
+			finder := fmt.Sprintf("%s%s", prefix, inflector.Pluralize(record.name()))
+
+			lookup := fmt.Sprintf("%s.%s(%s)", receiver, finder, blueprint)
+
+			if record.contextual() {
+				lookup = fmt.Sprintf("%s.%sContext(%s, %s)", receiver, finder, ctx, blueprint)
+			}
+
+			gosrc.Println("%s, %s := %s", symbols.related, symbols.e, lookup)
+
+			gosrc.WithIf("%s != nil", func(url.Values) error {
+				return gosrc.Returns(writing.Nil, symbols.e)
+			}, symbols.e)
+
+			gosrc.WithIter("_, %s := range %s", func(url.Values) error {
+				return gosrc.Println(
+					"%s[%s.%s] = append(%s[%s.%s], %s)",
+					symbols.results,
+					symbols.item,
+					rel.field,
+					symbols.results,
+					symbols.item,
+					rel.field,
+					symbols.item,
+				)
+			}, symbols.item, symbols.related)
+
+			return gosrc.Returns(symbols.results, writing.Nil)
+		})
+
+		if e == nil {
+			record.registerImports("math")
+		}
+
+		pw.CloseWithError(e)
+	}()
+
+	return pr
+}
+
+// newRelatableGenerator returns a reader that generates the belongs-to & has-many lookups for each of the record's
+// fields that reference another record.
+func newRelatableGenerator(record marlowRecord) io.Reader {
+	relations := record.relations()
+	readers := make([]io.Reader, 0, len(relations)*2)
+
+	for _, rel := range relations {
+		readers = append(readers, belongsTo(record, rel), hasMany(record, rel))
+	}
+
+	return io.MultiReader(readers...)
+}
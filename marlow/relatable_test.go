@@ -0,0 +1,204 @@
+package marlow
+
+import "io"
+import "fmt"
+import "sync"
+import "bytes"
+import "testing"
+import "strings"
+import "net/url"
+import "go/token"
+import "go/parser"
+import "github.com/franela/goblin"
+import "github.com/dadleyy/marlow/marlow/writing"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+type relatableTestScaffold struct {
+	buffer *bytes.Buffer
+
+	imports chan string
+	methods chan writing.FuncDecl
+
+	record url.Values
+	fields map[string]url.Values
+
+	packageRecords map[string]url.Values
+
+	received map[string]bool
+	closed   bool
+	wg       *sync.WaitGroup
+}
+
+func (s *relatableTestScaffold) close() {
+	if s == nil || s.closed {
+		return
+	}
+
+	s.closed = true
+	close(s.imports)
+	close(s.methods)
+	s.wg.Wait()
+}
+
+func (s *relatableTestScaffold) g() io.Reader {
+	record := marlowRecord{
+		fields:         s.fields,
+		config:         s.record,
+		packageRecords: s.packageRecords,
+		importChannel:  s.imports,
+		storeChannel:   s.methods,
+	}
+
+	return newRelatableGenerator(record)
+}
+
+func Test_Relatable(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	var scaffold *relatableTestScaffold
+
+	g.Describe("relatable feature generator test suite", func() {
+
+		g.BeforeEach(func() {
+			scaffold = &relatableTestScaffold{
+				buffer: new(bytes.Buffer),
+				wg:     &sync.WaitGroup{},
+
+				imports: make(chan string),
+				methods: make(chan writing.FuncDecl),
+
+				record:         make(url.Values),
+				fields:         make(map[string]url.Values),
+				packageRecords: make(map[string]url.Values),
+				received:       make(map[string]bool),
+				closed:         false,
+			}
+
+			scaffold.wg.Add(2)
+
+			go func() {
+				for range scaffold.methods {
+				}
+				scaffold.wg.Done()
+			}()
+
+			go func() {
+				for i := range scaffold.imports {
+					scaffold.received[i] = true
+				}
+				scaffold.wg.Done()
+			}()
+		})
+
+		g.AfterEach(func() {
+			scaffold.close()
+		})
+
+		g.Describe("with a valid record config", func() {
+
+			g.BeforeEach(func() {
+				scaffold.record.Set(constants.RecordNameConfigOption, "Book")
+				scaffold.record.Set(constants.TableNameConfigOption, "books")
+				scaffold.record.Set(constants.StoreNameConfigOption, "BookStore")
+				scaffold.record.Set(constants.BlueprintNameConfigOption, "BookBlueprint")
+				scaffold.record.Set(constants.StoreFindMethodPrefixConfigOption, "Find")
+
+				scaffold.fields["ID"] = url.Values{
+					"type":   []string{"int"},
+					"column": []string{"id"},
+				}
+
+				scaffold.fields["AuthorID"] = url.Values{
+					"type":                           []string{"int"},
+					"column":                         []string{"author"},
+					constants.ColumnReferencesOption: []string{"Author.ID"},
+				}
+			})
+
+			g.It("generates valid golang", func() {
+				fmt.Fprintln(scaffold.buffer, "package marlowt")
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				_, e = parser.ParseFile(token.NewFileSet(), "", scaffold.buffer, parser.AllErrors)
+				g.Assert(e).Equal(nil)
+			})
+
+			g.It("generates the belongs-to lookup using the store of the referenced record", func() {
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				source := scaffold.buffer.String()
+				g.Assert(strings.Contains(source, "FindBookAuthors(_records []*Book) (map[int]*Author,error)")).Equal(true)
+				g.Assert(strings.Contains(source, "NewAuthorStore(")).Equal(true)
+				g.Assert(strings.Contains(source, ".WithTx(")).Equal(true)
+				g.Assert(strings.Contains(source, "&AuthorBlueprint{ID: _keys, Limit: len(_keys)}")).Equal(true)
+			})
+
+			g.It("generates the unlimited has-many lookup on the store of the record", func() {
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				source := scaffold.buffer.String()
+				g.Assert(strings.Contains(source, "FindAuthorBooks(_records []*Author) (map[int][]*Book,error)")).Equal(true)
+				g.Assert(strings.Contains(source, "&BookBlueprint{AuthorID: _keys, Limit: math.MaxInt32}")).Equal(true)
+				scaffold.close()
+				g.Assert(scaffold.received["math"]).Equal(true)
+			})
+
+			g.It("uses the context-aware finder of the referenced record's store", func() {
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				g.Assert(strings.Contains(scaffold.buffer.String(), "_store.FindAuthorsContext(_ctx, ")).Equal(true)
+			})
+
+			g.Describe("with the referenced record declared in the package", func() {
+				g.BeforeEach(func() {
+					author := newRecordConfig("Author")
+					author.Set(constants.StoreNameConfigOption, "Writers")
+					author.Set(constants.BlueprintNameConfigOption, "WriterQuery")
+					author.Set(constants.StoreFindMethodPrefixConfigOption, "Lookup")
+					scaffold.packageRecords["Author"] = author
+				})
+
+				g.It("uses the store, blueprint & finder names of the referenced record", func() {
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					source := scaffold.buffer.String()
+					g.Assert(strings.Contains(source, "NewWriters(")).Equal(true)
+					g.Assert(strings.Contains(source, "&WriterQuery{ID: _keys, Limit: len(_keys)}")).Equal(true)
+					g.Assert(strings.Contains(source, "_store.LookupAuthorsContext(_ctx, ")).Equal(true)
+				})
+
+				g.It("does not send the context to referenced records without context-aware methods", func() {
+					scaffold.packageRecords["Author"].Set(constants.ContextMethodsConfigOption, "false")
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					source := scaffold.buffer.String()
+					g.Assert(strings.Contains(source, "_store.LookupAuthors(&WriterQuery")).Equal(true)
+					g.Assert(strings.Contains(source, "LookupAuthorsContext")).Equal(false)
+				})
+
+				g.It("sends a background context to the referenced records from records without context-aware methods", func() {
+					scaffold.record.Set(constants.ContextMethodsConfigOption, "false")
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "LookupAuthorsContext(context.Background(), ")).Equal(true)
+				})
+			})
+
+			g.It("falls back to the name of the referenced record when the field is named after the key", func() {
+				scaffold.fields["AuthorID"].Set(constants.ColumnReferencesOption, "Author.AuthorID")
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				source := scaffold.buffer.String()
+				g.Assert(strings.Contains(source, "FindBookAuthors(")).Equal(true)
+				g.Assert(strings.Contains(source, "FindAuthorBooks(")).Equal(true)
+			})
+
+			g.It("does not generate anything for records without references", func() {
+				scaffold.fields["AuthorID"].Del(constants.ColumnReferencesOption)
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				g.Assert(scaffold.buffer.Len()).Equal(0)
+			})
+		})
+	})
+}
@@ -9,10 +9,29 @@ import "github.com/dadleyy/marlow/marlow/constants"
 
 func writeStore(destination io.Writer, record marlowRecord, storeMethods map[string]writing.FuncDecl) error {
 	out := writing.NewGoWriter(destination)
+	executor := fmt.Sprintf("%sExecutor", record.store())
+
+	// The executor interface is satisfied by both *sql.DB and *sql.Tx, allowing the store to take part in transactions.
+	e := out.WithInterface(executor, func(url.Values) error {
+		out.Println("Prepare(string) (*sql.Stmt, error)")
+		out.Println("Exec(string, ...interface{}) (sql.Result, error)")
+		out.Println("Query(string, ...interface{}) (*sql.Rows, error)")
+		out.Println("PrepareContext(context.Context, string) (*sql.Stmt, error)")
+		out.Println("ExecContext(context.Context, string, ...interface{}) (sql.Result, error)")
+		out.Println("QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)")
+		return nil
+	})
+
+	if e != nil {
+		return e
+	}
 
-	e := out.WithStruct(record.store(), func(url.Values) error {
-		out.Println("*sql.DB")
+	e = out.WithStruct(record.store(), func(url.Values) error {
+		out.Println("%s", executor)
+		out.Println("%s *sql.DB", constants.StoreDatabaseField)
+		out.Println("%s *sql.Tx", constants.StoreTransactionField)
 		out.Println("%s io.Writer", constants.StoreLoggerField)
+		out.Println("%s func() time.Time", constants.StoreClockField)
 		return nil
 	})
 
@@ -23,7 +42,10 @@ func writeStore(destination io.Writer, record marlowRecord, storeMethods map[str
 	symbols := struct {
 		dbParam     string
 		queryLogger string
-	}{"_db", "_logger"}
+		txParam     string
+		clock       string
+		copy        string
+	}{"_db", "_logger", "_tx", "_clock", "_copy"}
 
 	params := []writing.FuncParam{
 		{Type: "*sql.DB", Symbol: symbols.dbParam},
@@ -38,11 +60,49 @@ func writeStore(destination io.Writer, record marlowRecord, storeMethods map[str
 		}, symbols.queryLogger)
 
 		return out.Println(
-			"return &%s{DB: %s, %s: %s}",
+			"return &%s{%s: %s, %s: %s, %s: %s, %s: time.Now}",
 			record.store(),
+			executor,
+			symbols.dbParam,
+			constants.StoreDatabaseField,
 			symbols.dbParam,
 			constants.StoreLoggerField,
 			symbols.queryLogger,
+			constants.StoreClockField,
+		)
+	})
+
+	if e != nil {
+		return e
+	}
+
+	methods := make(map[string]writing.FuncDecl, len(storeMethods)+3)
+
+	for name, method := range storeMethods {
+		methods[name] = method
+	}
+
+	txParams := []writing.FuncParam{{Type: "*sql.Tx", Symbol: symbols.txParam}}
+
+	e = out.WithMethod("WithTx", record.store(), txParams, returns, func(scope url.Values) error {
+		receiver := scope.Get("receiver")
+
+		return out.Println(
+			"return &%s{%s: %s, %s: %s.%s, %s: %s, %s: %s.%s, %s: %s.%s}",
+			record.store(),
+			executor,
+			symbols.txParam,
+			constants.StoreDatabaseField,
+			receiver,
+			constants.StoreDatabaseField,
+			constants.StoreTransactionField,
+			symbols.txParam,
+			constants.StoreLoggerField,
+			receiver,
+			constants.StoreLoggerField,
+			constants.StoreClockField,
+			receiver,
+			constants.StoreClockField,
 		)
 	})
 
@@ -50,8 +110,27 @@ func writeStore(destination io.Writer, record marlowRecord, storeMethods map[str
 		return e
 	}
 
+	methods["WithTx"] = writing.FuncDecl{Name: "WithTx", Params: txParams, Returns: returns}
+
+	clockParams := []writing.FuncParam{{Type: "func() time.Time", Symbol: symbols.clock}}
+
+	// The WithClock method returns a copy of the store that reads the current time (used for the createdAt, updatedAt &
+	// soft deletion columns) from the provided function rather than time.Now, e.g. for deterministic values during tests.
+	e = out.WithMethod("WithClock", record.store(), clockParams, returns, func(scope url.Values) error {
+		receiver := scope.Get("receiver")
+		out.Println("%s := *%s", symbols.copy, receiver)
+		out.Println("%s.%s = %s", symbols.copy, constants.StoreClockField, symbols.clock)
+		return out.Returns(fmt.Sprintf("&%s", symbols.copy))
+	})
+
+	if e != nil {
+		return e
+	}
+
+	methods["WithClock"] = writing.FuncDecl{Name: "WithClock", Params: clockParams, Returns: returns}
+
 	e = out.WithInterface(record.external(), func(url.Values) error {
-		for _, method := range storeMethods {
+		for _, method := range methods {
 			params := make([]string, 0, len(method.Params))
 			returns := strings.Join(method.Returns, ",")
 
@@ -64,12 +143,12 @@ func writeStore(destination io.Writer, record marlowRecord, storeMethods map[str
 			}
 
 			definition := fmt.Sprintf("%s(%s) %s", method.Name, strings.Join(params, ","), returns)
-			out.Println(definition)
+			out.Println("%s", definition)
 		}
 		return nil
 	})
 
-	record.registerImports("database/sql", "io", "os")
+	record.registerImports("context", "database/sql", "io", "os", "time")
 	return e
 }
 
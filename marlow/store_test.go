@@ -4,6 +4,7 @@ import "fmt"
 import "io"
 import "sync"
 import "bytes"
+import "strings"
 import "net/url"
 import "testing"
 import "go/ast"
@@ -96,10 +97,28 @@ func Test_StoreGenerator(t *testing.T) {
 			g.It("injects fmt and sql packages into import stream", func() {
 				io.Copy(scaffold.output, scaffold.g())
 				scaffold.close()
+				g.Assert(scaffold.received["context"]).Equal(true)
 				g.Assert(scaffold.received["database/sql"]).Equal(true)
 				g.Assert(scaffold.received["io"]).Equal(true)
 				g.Assert(scaffold.received["os"]).Equal(true)
-				g.Assert(len(scaffold.received)).Equal(3)
+				g.Assert(scaffold.received["time"]).Equal(true)
+				g.Assert(len(scaffold.received)).Equal(5)
+			})
+
+			g.It("writes the transaction method into the store interface", func() {
+				io.Copy(scaffold.output, scaffold.g())
+				g.Assert(strings.Contains(scaffold.output.String(), "WithTx(*sql.Tx) (BookStore)")).Equal(true)
+			})
+
+			g.It("leaves the transaction helper to the shared stores struct", func() {
+				io.Copy(scaffold.output, scaffold.g())
+				g.Assert(strings.Contains(scaffold.output.String(), "Transaction(")).Equal(false)
+			})
+
+			g.It("writes the clock method into the store interface", func() {
+				io.Copy(scaffold.output, scaffold.g())
+				g.Assert(strings.Contains(scaffold.output.String(), "WithClock(func() time.Time) (BookStore)")).Equal(true)
+				g.Assert(strings.Contains(scaffold.output.String(), "clock: time.Now")).Equal(true)
 			})
 
 			g.It("writes valid golang code if store name is present", func() {
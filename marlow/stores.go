@@ -0,0 +1,227 @@
+package marlow
+
+import "io"
+import "fmt"
+import "sort"
+import "strings"
+import "net/url"
+import "path/filepath"
+import "go/ast"
+import "go/token"
+import "github.com/gedex/inflector"
+import "github.com/dadleyy/marlow/marlow/writing"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+// packageRecord structs hold the record level configuration, enum fields & versioning of a record declared in one of
+// the files of a package.
+type packageRecord struct {
+	file      string
+	config    url.Values
+	enums     []packageEnum
+	versioned bool
+}
+
+// readPackageRecords returns the records declared by the files of a package. Ignored sources & the code generated by
+// marlow are skipped; neither of them declare records.
+func readPackageRecords(fs *token.FileSet, files ...*ast.File) []packageRecord {
+	records := make([]packageRecord, 0, len(files))
+
+	for _, file := range files {
+		if !compiledSource(file) {
+			continue
+		}
+
+		name := filepath.Base(fs.Position(file.Pos()).Filename)
+
+		for _, d := range file.Decls {
+			structType, typeName, ok := parseStruct(d)
+
+			if !ok {
+				continue
+			}
+
+			config := parseRecordConfig(structType, typeName)
+			config[fieldTypesConfigKey] = parseFieldTypes(structType)
+
+			records = append(records, packageRecord{
+				file:      name,
+				config:    config,
+				enums:     parseEnums(structType, typeName),
+				versioned: versionedStruct(structType, config),
+			})
+		}
+	}
+
+	return records
+}
+
+// compiledSource returns false for the sources skipped by the compiler: ignored sources & previously generated code.
+func compiledSource(file *ast.File) bool {
+	for _, c := range file.Comments {
+		text := c.Text()
+
+		if strings.Contains(text, constants.IgnoreSourceDirective) || strings.Contains(text, constants.CompilerHeader) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sharedStores returns the records whose stores are grouped by the shared stores struct of the package if the struct
+// is declared in the generated code of the file. The struct is declared once per package, alongside the records of
+// the first file (by name) with a record that generates its store & opts into the shared stores.
+func sharedStores(file string, records []packageRecord) ([]packageRecord, bool) {
+	stores, owner := make([]packageRecord, 0, len(records)), ""
+
+	for _, r := range records {
+		if r.config.Get(constants.SharedStoresConfigOption) != "true" || !generatesStore(r.config) {
+			continue
+		}
+
+		if owner == "" || r.file < owner {
+			owner = r.file
+		}
+
+		stores = append(stores, r)
+	}
+
+	return stores, len(stores) > 0 && owner == filepath.Base(file)
+}
+
+// newStoresGenerator returns a reader that will generate the struct grouping the stores of the provided records,
+// allowing the stores to take part in a single transaction.
+func newStoresGenerator(records []packageRecord, imports chan<- string) io.Reader {
+	pr, pw := io.Pipe()
+
+	go func() {
+		e := writeStores(pw, records, imports)
+		pw.CloseWithError(e)
+	}()
+
+	return pr
+}
+
+func writeStores(destination io.Writer, records []packageRecord, imports chan<- string) error {
+	out, storesType := writing.NewGoWriter(destination), constants.SharedStoresName
+	fields, stores := make([]string, 0, len(records)), make(map[string]*marlowRecord, len(records))
+
+	for _, r := range records {
+		record := &marlowRecord{config: r.config}
+		field := inflector.Pluralize(record.name())
+		fields, stores[field] = append(fields, field), record
+	}
+
+	sort.Strings(fields)
+
+	symbols := struct {
+		dbParam     string
+		queryLogger string
+		txParam     string
+		block       string
+		txError     string
+		recovered   string
+	}{"_db", "_logger", "_tx", "_block", "_e", "_recovered"}
+
+	out.Comment("%s groups the stores generated for the records of the package that opt into it.", storesType)
+	e := out.WithStruct(storesType, func(url.Values) error {
+		for _, field := range fields {
+			out.Println("%s %s", field, stores[field].external())
+		}
+
+		out.Println("%s *sql.DB", constants.StoreDatabaseField)
+		out.Println("%s *sql.Tx", constants.StoreTransactionField)
+		return nil
+	})
+
+	if e != nil {
+		return e
+	}
+
+	params := []writing.FuncParam{
+		{Type: "*sql.DB", Symbol: symbols.dbParam},
+		{Type: "io.Writer", Symbol: symbols.queryLogger},
+	}
+
+	constructor, returns := fmt.Sprintf("New%s", storesType), []string{fmt.Sprintf("*%s", storesType)}
+
+	e = out.WithFunc(constructor, params, returns, func(url.Values) error {
+		values := make([]string, 0, len(fields)+1)
+
+		for _, field := range fields {
+			store := fmt.Sprintf("New%s(%s, %s)", stores[field].external(), symbols.dbParam, symbols.queryLogger)
+			values = append(values, fmt.Sprintf("%s: %s", field, store))
+		}
+
+		values = append(values, fmt.Sprintf("%s: %s", constants.StoreDatabaseField, symbols.dbParam))
+		return out.Returns(fmt.Sprintf("&%s{%s}", storesType, strings.Join(values, ",")))
+	})
+
+	if e != nil {
+		return e
+	}
+
+	txParams := []writing.FuncParam{{Type: "*sql.Tx", Symbol: symbols.txParam}}
+
+	// The WithTx method returns a copy of the stores, each of them sending its queries through the transaction.
+	e = out.WithValueMethod("WithTx", storesType, txParams, []string{storesType}, func(scope url.Values) error {
+		receiver := scope.Get("receiver")
+		values := make([]string, 0, len(fields)+2)
+
+		for _, field := range fields {
+			values = append(values, fmt.Sprintf("%s: %s.%s.WithTx(%s)", field, receiver, field, symbols.txParam))
+		}
+
+		values = append(
+			values,
+			fmt.Sprintf("%s: %s.%s", constants.StoreDatabaseField, receiver, constants.StoreDatabaseField),
+			fmt.Sprintf("%s: %s", constants.StoreTransactionField, symbols.txParam),
+		)
+
+		return out.Returns(fmt.Sprintf("%s{%s}", storesType, strings.Join(values, ",")))
+	})
+
+	if e != nil {
+		return e
+	}
+
+	blockParams := []writing.FuncParam{
+		{Type: fmt.Sprintf("func(%s) error", storesType), Symbol: symbols.block},
+	}
+
+	// The Transaction method runs the block against transaction-bound copies of the stores, committing on success and
+	// rolling back if the block returns an error or panics. Stores already bound to a transaction simply run the block.
+	e = out.WithValueMethod("Transaction", storesType, blockParams, []string{"error"}, func(scope url.Values) error {
+		receiver := scope.Get("receiver")
+
+		out.WithIf("%s.%s != nil", func(url.Values) error {
+			return out.Returns(fmt.Sprintf("%s(%s)", symbols.block, receiver))
+		}, receiver, constants.StoreTransactionField)
+
+		out.Println("%s, %s := %s.%s.Begin()", symbols.txParam, symbols.txError, receiver, constants.StoreDatabaseField)
+
+		out.WithIf("%s != nil", func(url.Values) error {
+			return out.Returns(symbols.txError)
+		}, symbols.txError)
+
+		out.Println("defer func() {")
+		out.WithIf("%s := recover(); %s != nil", func(url.Values) error {
+			out.Println("%s.Rollback()", symbols.txParam)
+			return out.Println("panic(%s)", symbols.recovered)
+		}, symbols.recovered, symbols.recovered)
+		out.Println("}()\n")
+
+		out.WithIf("%s := %s(%s.WithTx(%s)); %s != nil", func(url.Values) error {
+			out.Println("%s.Rollback()", symbols.txParam)
+			return out.Returns(symbols.txError)
+		}, symbols.txError, symbols.block, receiver, symbols.txParam, symbols.txError)
+
+		return out.Returns(fmt.Sprintf("%s.Commit()", symbols.txParam))
+	})
+
+	for _, name := range []string{"database/sql", "io"} {
+		imports <- name
+	}
+
+	return e
+}
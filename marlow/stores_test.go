@@ -0,0 +1,181 @@
+package marlow
+
+import "io"
+import "fmt"
+import "sync"
+import "bytes"
+import "strings"
+import "net/url"
+import "testing"
+import "go/token"
+import "go/parser"
+import "github.com/franela/goblin"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+type storesTestScaffold struct {
+	output   *bytes.Buffer
+	imports  chan string
+	records  []packageRecord
+	received map[string]bool
+	closed   bool
+	wg       *sync.WaitGroup
+}
+
+func (s *storesTestScaffold) g() io.Reader {
+	return newStoresGenerator(s.records, s.imports)
+}
+
+func (s *storesTestScaffold) close() {
+	s.closed = true
+	close(s.imports)
+	s.wg.Wait()
+}
+
+func (s *storesTestScaffold) record(file, name string, options ...string) packageRecord {
+	config := newRecordConfig(name)
+
+	for i := 0; i+1 < len(options); i += 2 {
+		config.Set(options[i], options[i+1])
+	}
+
+	return packageRecord{file: file, config: config}
+}
+
+func Test_StoresGenerator(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	var scaffold *storesTestScaffold
+
+	g.Describe("shared stores generator test suite", func() {
+
+		g.BeforeEach(func() {
+			scaffold = &storesTestScaffold{
+				output:   new(bytes.Buffer),
+				imports:  make(chan string),
+				received: make(map[string]bool),
+				closed:   false,
+				wg:       &sync.WaitGroup{},
+			}
+
+			scaffold.wg.Add(1)
+
+			go func() {
+				for i := range scaffold.imports {
+					scaffold.received[i] = true
+				}
+				scaffold.wg.Done()
+			}()
+
+			scaffold.records = []packageRecord{
+				scaffold.record("book.go", "Book"),
+				scaffold.record("author.go", "Author"),
+			}
+
+			fmt.Fprintln(scaffold.output, "package marlowt")
+		})
+
+		g.AfterEach(func() {
+			if scaffold.closed == false {
+				scaffold.close()
+			}
+		})
+
+		g.It("writes valid golang code", func() {
+			_, e := io.Copy(scaffold.output, scaffold.g())
+			g.Assert(e).Equal(nil)
+			_, e = parser.ParseFile(token.NewFileSet(), "", scaffold.output, parser.AllErrors)
+			g.Assert(e).Equal(nil)
+		})
+
+		g.It("injects the sql and io packages into the import stream", func() {
+			io.Copy(scaffold.output, scaffold.g())
+			scaffold.close()
+			g.Assert(scaffold.received["database/sql"]).Equal(true)
+			g.Assert(scaffold.received["io"]).Equal(true)
+			g.Assert(len(scaffold.received)).Equal(2)
+		})
+
+		g.It("groups the store of each record by its pluralized name", func() {
+			io.Copy(scaffold.output, scaffold.g())
+			g.Assert(strings.Contains(scaffold.output.String(), "Authors AuthorStore")).Equal(true)
+			g.Assert(strings.Contains(scaffold.output.String(), "Books BookStore")).Equal(true)
+			constructor := "Authors: NewAuthorStore(_db, _logger),Books: NewBookStore(_db, _logger),db: _db"
+			g.Assert(strings.Contains(scaffold.output.String(), constructor)).Equal(true)
+		})
+
+		g.It("binds every store to the transaction", func() {
+			io.Copy(scaffold.output, scaffold.g())
+			withTx := "Authors: s.Authors.WithTx(_tx),Books: s.Books.WithTx(_tx),db: s.db,tx: _tx"
+			g.Assert(strings.Contains(scaffold.output.String(), withTx)).Equal(true)
+		})
+
+		g.It("writes a transaction method accepting a block of the shared stores", func() {
+			io.Copy(scaffold.output, scaffold.g())
+			g.Assert(strings.Contains(scaffold.output.String(), "Transaction(_block func(Stores) error) error")).Equal(true)
+		})
+
+		g.It("rolls the transaction back before re-panicking if the block panics", func() {
+			io.Copy(scaffold.output, scaffold.g())
+			recovery := "if _recovered := recover(); _recovered != nil {\n_tx.Rollback()\npanic(_recovered)"
+			g.Assert(strings.Contains(scaffold.output.String(), recovery)).Equal(true)
+		})
+	})
+
+	g.Describe("sharedStores", func() {
+		var records []packageRecord
+
+		g.BeforeEach(func() {
+			scaffold = &storesTestScaffold{}
+			shared := constants.SharedStoresConfigOption
+			records = []packageRecord{
+				scaffold.record("book.go", "Book", shared, "true"),
+				scaffold.record("author.go", "Author", shared, "true", "queryable", "false"),
+				scaffold.record("author.go", "Review", shared, "true"),
+			}
+		})
+
+		g.It("declares the shared stores alongside the records of the first file", func() {
+			stores, ok := sharedStores("models/author.go", records)
+			g.Assert(ok).Equal(true)
+			g.Assert(len(stores)).Equal(3)
+
+			_, ok = sharedStores("models/book.go", records)
+			g.Assert(ok).Equal(false)
+		})
+
+		g.It("skips the records that do not generate a store", func() {
+			records[1].config = url.Values{
+				constants.SharedStoresConfigOption: {"true"},
+				constants.CreateableConfigOption:   {"false"},
+				constants.UpdateableConfigOption:   {"false"},
+				constants.DeleteableConfigOption:   {"false"},
+				constants.QueryableConfigOption:    {"false"},
+			}
+
+			records = records[:2]
+
+			stores, ok := sharedStores("book.go", records)
+			g.Assert(ok).Equal(true)
+			g.Assert(len(stores)).Equal(1)
+		})
+
+		g.It("only groups the stores of the records opting into the shared stores", func() {
+			records[0].config.Del(constants.SharedStoresConfigOption)
+			records[1].config.Set(constants.SharedStoresConfigOption, "false")
+
+			stores, ok := sharedStores("author.go", records)
+			g.Assert(ok).Equal(true)
+			g.Assert(len(stores)).Equal(1)
+			g.Assert(stores[0].config.Get(constants.RecordNameConfigOption)).Equal("Review")
+
+			records[2].config.Del(constants.SharedStoresConfigOption)
+			_, ok = sharedStores("author.go", records)
+			g.Assert(ok).Equal(false)
+		})
+
+		g.It("does not declare the shared stores if no record generates a store", func() {
+			_, ok := sharedStores("book.go", nil)
+			g.Assert(ok).Equal(false)
+		})
+	})
+}
@@ -0,0 +1,39 @@
+package marlow
+
+import "net/url"
+import "github.com/dadleyy/marlow/marlow/writing"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+// timestampFlags are the field flags marking the time.Time fields that are set to the current time of the store.
+var timestampFlags = []string{constants.ColumnCreatedAtFlag, constants.ColumnUpdatedAtFlag}
+
+// writeCurrentTime writes the assignment of the store's current time to the now symbol, provided the record has a field
+// flagged with any of the timestamp flags.
+func writeCurrentTime(gosrc writing.GoWriter, record marlowRecord, receiver, now string, flags ...string) {
+	if len(record.timestampFields(flags...)) == 0 {
+		return
+	}
+
+	gosrc.Println("%s := %s.%s()", now, receiver, constants.StoreClockField)
+}
+
+// writeTimestampDefaults writes the assignments setting each of the zero-valued createdAt & updatedAt fields of the
+// target record to the current time. Fields flagged with any of the overwritten flags are always set.
+func writeTimestampDefaults(gosrc writing.GoWriter, record marlowRecord, target, now string, overwritten ...string) {
+	overwrite := make(map[string]bool, len(overwritten))
+
+	for _, f := range record.timestampFields(overwritten...) {
+		overwrite[f.name] = true
+	}
+
+	for _, f := range record.timestampFields(timestampFlags...) {
+		if overwrite[f.name] {
+			gosrc.Println("%s.%s = %s", target, f.name, now)
+			continue
+		}
+
+		gosrc.WithIf("%s.%s.IsZero()", func(url.Values) error {
+			return gosrc.Println("%s.%s = %s", target, f.name, now)
+		}, target, f.name)
+	}
+}
@@ -1,6 +1,12 @@
 package marlow
 
+import "sync"
+import "strings"
+import "go/ast"
+import "go/token"
 import "go/types"
+import "net/url"
+import "go/importer"
 import "github.com/dadleyy/marlow/marlow/constants"
 
 // getTypeInfo returns a types.BasicInfo mask value based on the string provided.
@@ -25,3 +31,176 @@ func getTypeInfo(fieldType string) types.BasicInfo {
 
 	return typeInfo
 }
+
+// fieldTypeInfo returns the types.BasicInfo mask value of a field. Fields read with type information are classified by
+// their underlying type (e.g. `type Status string` is a string), others by the name of their type. The nullable types
+// of database/sql are classified by the type of their value, while the `kind` option takes precedence over both.
+func fieldTypeInfo(config url.Values) types.BasicInfo {
+	if kind, ok := columnKinds[config.Get(constants.ColumnKindOption)]; ok {
+		return kind
+	}
+
+	if t, ok := sqlNullField(config); ok {
+		return getTypeInfo(t.valueType)
+	}
+
+	if underlying := config.Get("underlying"); underlying != "" {
+		return getTypeInfo(underlying)
+	}
+
+	return getTypeInfo(config.Get("type"))
+}
+
+// columnKinds are the types.BasicInfo mask values used to classify the fields configured with the `kind` option.
+var columnKinds = map[string]types.BasicInfo{
+	constants.StringColumnKind:  types.IsString,
+	constants.NumericColumnKind: types.IsNumeric,
+	constants.OpaqueColumnKind:  0,
+}
+
+// comparableField returns true for fields that can be matched using IN clauses; the fields of basic types & the fields
+// configured with the `kind` option.
+func comparableField(config url.Values) bool {
+	_, kind := columnKinds[config.Get(constants.ColumnKindOption)]
+	return kind || fieldTypeInfo(config)&types.IsConstType != 0
+}
+
+// sqlNullType holds the name & type of the field holding the value of one of the nullable types of database/sql.
+type sqlNullType struct {
+	field     string
+	valueType string
+}
+
+// sqlNullTypes are the nullable types of the database/sql package, keyed by their package-qualified name.
+var sqlNullTypes = map[string]sqlNullType{
+	"sql.NullString":  {"String", "string"},
+	"sql.NullInt64":   {"Int64", "int64"},
+	"sql.NullInt32":   {"Int32", "int32"},
+	"sql.NullInt16":   {"Int16", "int16"},
+	"sql.NullFloat64": {"Float64", "float64"},
+	"sql.NullBool":    {"Bool", "bool"},
+	"sql.NullTime":    {"Time", "time.Time"},
+}
+
+// sqlNullField returns the nullable database/sql type of a field, if the field has one.
+func sqlNullField(config url.Values) (sqlNullType, bool) {
+	name := config.Get("underlying")
+
+	if name == "" {
+		name = config.Get("type")
+	}
+
+	t, ok := sqlNullTypes[name]
+	return t, ok
+}
+
+// pointerField returns true for fields whose type is a pointer; these map nullable columns (nil is stored as NULL).
+func pointerField(config url.Values) bool {
+	return strings.HasPrefix(config.Get("type"), "*")
+}
+
+// nullableField returns true for fields that map nullable columns; pointers & the nullable types of database/sql.
+func nullableField(config url.Values) bool {
+	_, ok := sqlNullField(config)
+	return ok || pointerField(config)
+}
+
+// fieldValueType returns the type of the non-null values of a field; the type pointed to by pointer fields and the type
+// of the value field of the nullable database/sql types (e.g. `string` for `sql.NullString`).
+func fieldValueType(config url.Values) string {
+	if t, ok := sqlNullField(config); ok {
+		return t.valueType
+	}
+
+	return strings.TrimPrefix(config.Get("type"), "*")
+}
+
+// underlyingTypeName returns the name used to classify the type of a field expression; the basic type beneath named
+// types & aliases or the package-qualified name of any other type (e.g. `time.Time`). An empty string is returned when
+// the expression was not successfully type checked.
+func underlyingTypeName(info *types.Info, expr ast.Expr) string {
+	if info == nil {
+		return ""
+	}
+
+	t := info.TypeOf(expr)
+
+	if t == nil {
+		return ""
+	}
+
+	if basic, ok := t.Underlying().(*types.Basic); ok {
+		if basic.Kind() == types.Invalid {
+			return ""
+		}
+
+		// Use the name of the kind; the names of the byte & rune aliases are not found in types.Typ.
+		return types.Typ[basic.Kind()].Name()
+	}
+
+	return types.TypeString(t, func(p *types.Package) string { return p.Name() })
+}
+
+// typeConstants returns the names of the constants declared for the (named) type of an expression in the package of
+// the type, sorted by name. Without type information no constants are returned.
+func typeConstants(info *types.Info, expr ast.Expr) []string {
+	if info == nil {
+		return nil
+	}
+
+	named, ok := info.TypeOf(expr).(*types.Named)
+
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+
+	scope := named.Obj().Pkg().Scope()
+	names := make([]string, 0, len(scope.Names()))
+
+	for _, name := range scope.Names() {
+		if c, ok := scope.Lookup(name).(*types.Const); ok && types.Identical(c.Type(), named) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// packageImporter loads the packages imported by the compiled sources, preferring the export data produced by the
+// compiler over type checking the imported package from its source.
+type packageImporter struct {
+	sync.Mutex
+	compiled types.Importer
+	source   types.Importer
+}
+
+func (i *packageImporter) Import(path string) (*types.Package, error) {
+	i.Lock()
+	defer i.Unlock()
+
+	if pkg, e := i.compiled.Import(path); e == nil {
+		return pkg, nil
+	}
+
+	return i.source.Import(path)
+}
+
+// sharedImporter is used by every compilation; the imported packages are cached between sources of the same package.
+var sharedImporter = &packageImporter{
+	compiled: importer.ForCompiler(token.NewFileSet(), "gc", nil),
+	source:   importer.ForCompiler(token.NewFileSet(), "source", nil),
+}
+
+// checkTypes type checks the files of a package, returning the type information of their expressions. Errors are
+// ignored; expressions that could not be checked (e.g. types declared in files that were not provided) are left
+// without type information.
+func checkTypes(fs *token.FileSet, files ...*ast.File) *types.Info {
+	info := &types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+	config := types.Config{Importer: sharedImporter, Error: func(error) {}}
+
+	if len(files) > 0 {
+		config.Check(files[0].Name.String(), fs, files, info)
+	}
+
+	return info
+}
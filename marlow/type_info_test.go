@@ -1,7 +1,11 @@
 package marlow
 
 import "testing"
+import "go/ast"
+import "net/url"
+import "go/token"
 import "go/types"
+import "go/parser"
 import "github.com/franela/goblin"
 
 func Test_getTypeInfo(t *testing.T) {
@@ -28,4 +32,147 @@ func Test_getTypeInfo(t *testing.T) {
 			g.Assert(v & types.IsNumeric).Equal(v)
 		})
 	})
+
+	g.Describe("fieldTypeInfo", func() {
+		g.It("uses the underlying type of the field when present", func() {
+			v := fieldTypeInfo(url.Values{"type": []string{"Status"}, "underlying": []string{"string"}})
+			g.Assert(v & types.IsString).Equal(types.IsString)
+		})
+
+		g.It("falls back to the name of the field type", func() {
+			v := fieldTypeInfo(url.Values{"type": []string{"uint8"}})
+			g.Assert(v & types.IsUnsigned).Equal(types.IsUnsigned)
+		})
+
+		g.It("uses the value type of the database/sql nullable types", func() {
+			v := fieldTypeInfo(url.Values{"type": []string{"sql.NullFloat64"}})
+			g.Assert(v & types.IsFloat).Equal(types.IsFloat)
+		})
+
+		g.It("uses the kind option of the field when present", func() {
+			v := fieldTypeInfo(url.Values{"type": []string{"UUID"}, "kind": []string{"string"}})
+			g.Assert(v).Equal(types.IsString)
+
+			v = fieldTypeInfo(url.Values{"type": []string{"Money"}, "kind": []string{"numeric"}})
+			g.Assert(v).Equal(types.IsNumeric)
+		})
+	})
+
+	g.Describe("comparableField", func() {
+		g.It("returns true for basic types", func() {
+			g.Assert(comparableField(url.Values{"type": []string{"bool"}})).Equal(true)
+		})
+
+		g.It("returns true for opaque fields", func() {
+			g.Assert(comparableField(url.Values{"type": []string{"Token"}, "kind": []string{"opaque"}})).Equal(true)
+		})
+
+		g.It("returns false for other types", func() {
+			g.Assert(comparableField(url.Values{"type": []string{"Token"}})).Equal(false)
+		})
+	})
+
+	g.Describe("underlyingTypeName", func() {
+		var fields map[string]ast.Expr
+		var info *types.Info
+
+		g.Before(func() {
+			fs := token.NewFileSet()
+			source := `
+				package marlowt
+				import "time"
+				type Status string
+				type Level = uint16
+				type Construct struct {
+					Status    Status
+					Level     Level
+					Flags     byte
+					CreatedAt time.Time
+					Missing   Undeclared
+				}
+			`
+			file, e := parser.ParseFile(fs, "", source, 0)
+			g.Assert(e).Equal(nil)
+			info = checkTypes(fs, file)
+			fields = make(map[string]ast.Expr)
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				if f, ok := n.(*ast.Field); ok && len(f.Names) == 1 {
+					fields[f.Names[0].Name] = f.Type
+				}
+
+				return true
+			})
+		})
+
+		g.It("returns the basic type beneath named types", func() {
+			g.Assert(underlyingTypeName(info, fields["Status"])).Equal("string")
+		})
+
+		g.It("returns the basic type beneath aliases", func() {
+			g.Assert(underlyingTypeName(info, fields["Level"])).Equal("uint16")
+			g.Assert(underlyingTypeName(info, fields["Flags"])).Equal("uint8")
+		})
+
+		g.It("returns the package qualified name of other types", func() {
+			g.Assert(underlyingTypeName(info, fields["CreatedAt"])).Equal("time.Time")
+		})
+
+		g.It("returns an empty string for types that were not type checked", func() {
+			g.Assert(underlyingTypeName(info, fields["Missing"])).Equal("")
+			g.Assert(underlyingTypeName(nil, fields["Status"])).Equal("")
+		})
+	})
+
+	g.Describe("typeConstants", func() {
+		var fields map[string]ast.Expr
+		var info *types.Info
+
+		g.Before(func() {
+			fs := token.NewFileSet()
+			source := `
+				package marlowt
+				type Status string
+				type Level uint
+				const (
+					StatusPending Status = "pending"
+					StatusActive  Status = "active"
+					Untyped              = "archived"
+					LevelLow      Level  = 1
+				)
+				type Construct struct {
+					Status    Status
+					Level     *Level
+					Name      string
+					Missing   Undeclared
+				}
+			`
+			file, e := parser.ParseFile(fs, "", source, 0)
+			g.Assert(e).Equal(nil)
+			info = checkTypes(fs, file)
+			fields = make(map[string]ast.Expr)
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				if f, ok := n.(*ast.Field); ok && len(f.Names) == 1 {
+					fields[f.Names[0].Name] = f.Type
+				}
+
+				return true
+			})
+		})
+
+		g.It("returns the sorted names of the constants declared for the type", func() {
+			g.Assert(typeConstants(info, fields["Status"])).Equal([]string{"StatusActive", "StatusPending"})
+		})
+
+		g.It("returns nothing for types without declared constants", func() {
+			g.Assert(len(typeConstants(info, fields["Name"]))).Equal(0)
+			g.Assert(len(typeConstants(info, fields["Level"]))).Equal(0)
+		})
+
+		g.It("returns nothing for types that were not type checked", func() {
+			g.Assert(len(typeConstants(info, fields["Missing"]))).Equal(0)
+			g.Assert(len(typeConstants(nil, fields["Status"]))).Equal(0)
+		})
+	})
 }
@@ -2,7 +2,10 @@ package marlow
 
 import "io"
 import "fmt"
+import "strings"
 import "net/url"
+import "path/filepath"
+import "go/ast"
 import "go/types"
 import "github.com/dadleyy/marlow/marlow/writing"
 import "github.com/dadleyy/marlow/marlow/constants"
@@ -20,6 +23,9 @@ type updaterSymbols struct {
 	valueSlice      string
 	valueCount      string
 	targetValue     string
+	now             string
+	version         string
+	where           string
 }
 
 func updater(record marlowRecord, fieldConfig url.Values, methodName, op string) io.Reader {
@@ -39,6 +45,9 @@ func updater(record marlowRecord, fieldConfig url.Values, methodName, op string)
 		valueSlice:      "_values",
 		valueCount:      "_valueCount",
 		targetValue:     "_target",
+		now:             "_now",
+		version:         "_version",
+		where:           "_where",
 	}
 
 	params := []writing.FuncParam{
@@ -46,22 +55,55 @@ func updater(record marlowRecord, fieldConfig url.Values, methodName, op string)
 		{Type: fmt.Sprintf("*%s", record.config.Get(constants.BlueprintNameConfigOption)), Symbol: symbols.blueprint},
 	}
 
-	if fieldConfig.Get("type") == "sql.NullInt64" {
+	// The value sent to the database for the target column (json values are marshaled).
+	target := fieldValue(record, fieldConfig, symbols.valueParam)
+
+	// The nullable database/sql types are sent by reference; a nil value sets the column to NULL.
+	if _, ok := sqlNullField(fieldConfig); ok {
 		params[0].Type = fmt.Sprintf("*%s", fieldConfig.Get("type"))
 	}
 
+	versionName, versionConfig, versioned := record.versionField()
+
+	// Versioned records require the expected version of the rows being updated, sent between the value & blueprint.
+	if versioned {
+		version := writing.FuncParam{Type: versionConfig.Get("type"), Symbol: symbols.version}
+		params = []writing.FuncParam{params[0], version, params[1]}
+	}
+
 	returns := []string{
 		"int64",
 		"error",
 	}
 
+	// Every updatedAt timestamp column (other than the one being updated) is set to the current time of the store.
+	stamps := record.fieldList(func(config url.Values) bool {
+		_, stamp := config[constants.ColumnUpdatedAtFlag]
+		return stamp && config.Get(constants.ColumnConfigOption) != column
+	})
+
 	go func() {
 		gosrc := writing.NewGoWriter(pw)
 		gosrc.Comment("[marlow] updater method for %s", column)
 
-		e := gosrc.WithMethod(methodName, record.store(), params, returns, func(scope url.Values) error {
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
 			logwriter := logWriter{output: gosrc, receiver: scope.Get("receiver")}
 
+			// The version of versioned records is only meaningful for the rows the blueprint limits the update to.
+			if versioned {
+				gosrc.WithIf("%s == nil || %s.where(1) == \"\"", func(url.Values) error {
+					return gosrc.Returns("-1", fmt.Sprintf("fmt.Errorf(\"%s\")", constants.InvalidVersionedUpdateBlueprint))
+				}, symbols.blueprint, symbols.blueprint)
+			}
+
+			if e := writeJoinGuard(gosrc, record, symbols.blueprint); e != nil {
+				return e
+			}
+
+			writeEnumCheck(gosrc, record, fieldConfig, symbols.valueParam, func(e string) error {
+				return gosrc.Returns("-1", e)
+			})
+
 			// Prepare a value count to keep track of the amount of dynamic components will be sent into the query.
 			gosrc.Println("%s := 1", symbols.valueCount)
 
@@ -70,36 +112,71 @@ func updater(record marlowRecord, fieldConfig url.Values, methodName, op string)
 				return gosrc.Println("%s = len(%s.Values()) + 1", symbols.valueCount, symbols.blueprint)
 			}, symbols.blueprint, symbols.blueprint)
 
-			switch record.dialect() {
-			case "postgres":
-				gosrc.Println("%s := fmt.Sprintf(\"$%%d\", %s)", symbols.targetValue, symbols.valueCount)
-				break
-			default:
-				gosrc.Println("%s := \"?\"", symbols.targetValue)
-			}
+			gosrc.Println("%s := %s", symbols.targetValue, record.dialect().Placeholder(symbols.valueCount))
 
-			command := fmt.Sprintf("UPDATE %s SET %s = %%s", record.table(), column)
+			table := record.quote(record.table())
+			command := fmt.Sprintf("UPDATE %s SET %s = %%s", table, record.quote(column))
 
 			if op != "" {
-				command = fmt.Sprintf("UPDATE %s SET %s = %s", record.table(), column, op)
+				command = fmt.Sprintf("UPDATE %s SET %s = %s", table, record.quote(column), op)
+			}
+
+			placeholders := []string{symbols.targetValue}
+
+			// The timestamp values immediately follow the target value.
+			for i, stamp := range stamps {
+				position := fmt.Sprintf("%s+%d", symbols.valueCount, i+1)
+				stampColumn := record.quote(record.fields[stamp.name].Get(constants.ColumnConfigOption))
+				command = fmt.Sprintf("%s, %s = %%s", command, stampColumn)
+				placeholders = append(placeholders, record.dialect().Placeholder(position))
+			}
+
+			if len(stamps) > 0 {
+				writeCurrentTime(gosrc, record, scope.Get("receiver"), symbols.now, constants.ColumnUpdatedAtFlag)
+			}
+
+			versionColumn := record.quote(record.fields[versionName].Get(constants.ColumnConfigOption))
+
+			if versioned {
+				command = fmt.Sprintf("%s, %s = %s + 1", command, versionColumn, versionColumn)
 			}
 
 			// Start the update template string with the basic SQL-dialect `UPDATE <table> SET <column> = ?` syntax.
-			template := fmt.Sprintf("fmt.Sprintf(\"%s\", %s)", command, symbols.targetValue)
+			template := fmt.Sprintf("fmt.Sprintf(\"%s\", %s)", command, strings.Join(placeholders, ", "))
 
 			gosrc.Println("%s := bytes.NewBufferString(%s)", symbols.queryString, template)
 
 			// Add our blueprint to the WHERE section of our update statement buffer if it is not nil.
-			gosrc.WithIf("%s != nil", func(url.Values) error {
-				return gosrc.Println("fmt.Fprintf(%s, \" %%s\", %s)", symbols.queryString, symbols.blueprint)
-			}, symbols.blueprint)
+			if !versioned {
+				gosrc.WithIf("%s != nil", func(url.Values) error {
+					return gosrc.Println("fmt.Fprintf(%s, \" %%s\", %s)", symbols.queryString, symbols.blueprint)
+				}, symbols.blueprint)
+			}
+
+			// The version clause of versioned records is combined with the clauses of the blueprint; its value is always the
+			// last value sent to the database.
+			if versioned {
+				position := fmt.Sprintf("%s+%d", symbols.valueCount, len(stamps)+1)
+				clause := sqlExpression(fmt.Sprintf("%s = %%s", versionColumn), record.dialect().Placeholder(position))
+				gosrc.Println("%s := %s", symbols.where, clause)
+
+				gosrc.Println(
+					"%s = fmt.Sprintf(\"(%%s) AND %%s\", strings.TrimPrefix(%s.String(), \"WHERE \"), %s)",
+					symbols.where,
+					symbols.blueprint,
+					symbols.where,
+				)
+
+				gosrc.Println("fmt.Fprintf(%s, \" WHERE %%s\", %s)", symbols.queryString, symbols.where)
+			}
 
 			// Write the query execution statement.
 			gosrc.Println(
-				"%s, %s := %s.Prepare(%s.String() + \";\")",
+				"%s, %s := %s.PrepareContext(%s, %s.String() + \";\")",
 				symbols.statementResult,
 				symbols.statementError,
 				scope.Get("receiver"),
+				ctx,
 				symbols.queryString,
 			)
 
@@ -112,10 +189,10 @@ func updater(record marlowRecord, fieldConfig url.Values, methodName, op string)
 			// Create an array of `interface` values that will be used during the `Exec` portion of our transaction.
 			gosrc.Println("%s := make([]interface{}, 0, %s)", symbols.valueSlice, symbols.valueCount)
 
-			// The postgres dialect uses numbered placeholder values. If the record is using anything other than that, the
-			// placeholder for the target value should appear first in the set of values sent to Exec.
-			if record.dialect() != "postgres" {
-				gosrc.Println("%s = append(%s, %s)", symbols.valueSlice, symbols.valueSlice, symbols.valueParam)
+			// Unless the dialect uses numbered placeholder values, the placeholder for the target value appears first in the
+			// query and its value should appear first in the set of values sent to Exec.
+			if !record.dialect().NumberedPlaceholders() {
+				writeUpdaterValues(gosrc, symbols, target, len(stamps))
 			}
 
 			gosrc.WithIf("%s != nil", func(url.Values) error {
@@ -127,17 +204,22 @@ func updater(record marlowRecord, fieldConfig url.Values, methodName, op string)
 				)
 			}, symbols.blueprint)
 
-			// If we're postgres, add our value to the very end of our value slice.
-			if record.dialect() == "postgres" {
-				gosrc.Println("%s = append(%s, %s)", symbols.valueSlice, symbols.valueSlice, symbols.valueParam)
+			// With numbered placeholders, add our value to the very end of our value slice.
+			if record.dialect().NumberedPlaceholders() {
+				writeUpdaterValues(gosrc, symbols, target, len(stamps))
+			}
+
+			if versioned {
+				gosrc.Println("%s = append(%s, %s)", symbols.valueSlice, symbols.valueSlice, symbols.version)
 			}
 
 			logwriter.AddLog(symbols.queryString, symbols.valueSlice)
 
-			gosrc.Println("%s, %s := %s.Exec(%s...)",
+			gosrc.Println("%s, %s := %s.ExecContext(%s, %s...)",
 				symbols.queryResult,
 				symbols.queryError,
 				symbols.statementResult,
+				ctx,
 				symbols.valueSlice,
 			)
 
@@ -151,6 +233,12 @@ func updater(record marlowRecord, fieldConfig url.Values, methodName, op string)
 				return gosrc.Returns("-1", symbols.rowError)
 			}, symbols.rowError)
 
+			if versioned {
+				gosrc.WithIf("%s == 0", func(url.Values) error {
+					return gosrc.Returns("0", record.staleError())
+				}, symbols.rowCount)
+			}
+
 			return gosrc.Returns(symbols.rowCount, writing.Nil)
 		})
 
@@ -160,30 +248,296 @@ func updater(record marlowRecord, fieldConfig url.Values, methodName, op string)
 		}
 
 		record.registerImports("fmt", "bytes")
-		record.registerStoreMethod(writing.FuncDecl{
-			Name:    methodName,
-			Params:  params,
-			Returns: returns,
-		})
+
+		if versioned {
+			record.registerImports("strings")
+		}
+
 		pw.CloseWithError(nil)
 	}()
 
 	return pr
 }
 
+// writeUpdaterValues appends the target value of an updater to the value slice, followed by the current time for each
+// of the timestamp columns being set alongside it.
+func writeUpdaterValues(gosrc writing.GoWriter, symbols updaterSymbols, target string, stamps int) {
+	values := []string{target}
+
+	for i := 0; i < stamps; i++ {
+		values = append(values, symbols.now)
+	}
+
+	gosrc.Println("%s = append(%s, %s)", symbols.valueSlice, symbols.valueSlice, strings.Join(values, ", "))
+}
+
+// recordUpdater generates the Update<Record> method, writing every updateable column of the record in a single UPDATE
+// statement that targets the row by primary key.
+func recordUpdater(record marlowRecord) io.Reader {
+	pr, pw := io.Pipe()
+
+	keyField, keyConfig, ok := record.primaryKeyField()
+
+	if !ok {
+		pw.CloseWithError(nil)
+		return pr
+	}
+
+	// Skip auto increment columns, the primary key itself, createdAt timestamps, the version column and any field that
+	// has been flagged as `updateable=false`.
+	fields := record.fieldList(func(config url.Values) bool {
+		_, createdAt := config[constants.ColumnCreatedAtFlag]
+		_, version := config[constants.ColumnVersionFlag]
+
+		switch {
+		case config.Get(constants.ColumnAutoIncrementFlag) != "":
+			return false
+		case config.Get(constants.UpdateableConfigOption) == "false":
+			return false
+		case createdAt || version:
+			return false
+		}
+
+		return config.Get(constants.ColumnConfigOption) != keyConfig.Get(constants.ColumnConfigOption)
+	})
+
+	if len(fields) == 0 {
+		pw.CloseWithError(nil)
+		return pr
+	}
+
+	methodName := fmt.Sprintf("%s%s", record.config.Get(constants.UpdateFieldMethodPrefixConfigOption), record.name())
+
+	symbols := struct {
+		recordParam     string
+		queryString     string
+		values          string
+		statementResult string
+		statementError  string
+		queryResult     string
+		queryError      string
+		rowCount        string
+		rowError        string
+		now             string
+	}{"_record", "_queryString", "_values", "_statement", "_se", "_queryResult", "_queryError", "_rowCount", "_re", "_now"}
+
+	params := []writing.FuncParam{
+		{Type: fmt.Sprintf("*%s", record.name()), Symbol: symbols.recordParam},
+	}
+
+	returns := []string{
+		"int64",
+		"error",
+	}
+
+	assignments := make([]string, 0, len(fields))
+	placeholders := make([]string, 0, len(fields)+1)
+	values := make([]string, 0, len(fields)+1)
+
+	for i, f := range fields {
+		column := record.fields[f.name].Get(constants.ColumnConfigOption)
+		assignments = append(assignments, fmt.Sprintf("%s = %%s", record.quote(column)))
+		placeholders = append(placeholders, record.dialect().Placeholder(fmt.Sprintf("%d", i+1)))
+		values = append(values, fieldValue(record, record.fields[f.name], fmt.Sprintf("%s.%s", symbols.recordParam, f.name)))
+	}
+
+	placeholders = append(placeholders, record.dialect().Placeholder(fmt.Sprintf("%d", len(fields)+1)))
+	values = append(values, fmt.Sprintf("%s.%s", symbols.recordParam, keyField))
+
+	condition := fmt.Sprintf("%s = %%s", record.quote(keyConfig.Get(constants.ColumnConfigOption)))
+	versionName, versionConfig, versioned := record.versionField()
+
+	// Versioned records only update the row if it is still at the version of the record, incrementing the version.
+	if versioned {
+		versionColumn := record.quote(versionConfig.Get(constants.ColumnConfigOption))
+		assignments = append(assignments, fmt.Sprintf("%s = %s + 1", versionColumn, versionColumn))
+		condition = fmt.Sprintf("%s AND %s = %%s", condition, versionColumn)
+		placeholders = append(placeholders, record.dialect().Placeholder(fmt.Sprintf("%d", len(fields)+2)))
+		values = append(values, fmt.Sprintf("%s.%s", symbols.recordParam, versionName))
+	}
+
+	command := fmt.Sprintf(
+		"UPDATE %s SET %s WHERE %s;",
+		record.quote(record.table()),
+		strings.Join(assignments, ", "),
+		condition,
+	)
+
+	go func() {
+		gosrc := writing.NewGoWriter(pw)
+		gosrc.Comment("[marlow] record updater for %s", record.table())
+
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
+			logwriter := logWriter{output: gosrc, receiver: scope.Get("receiver")}
+
+			gosrc.WithIf("%s == nil", func(url.Values) error {
+				return gosrc.Returns("-1", fmt.Sprintf("fmt.Errorf(\"%s\")", constants.InvalidUpdateRecordError))
+			}, symbols.recordParam)
+
+			writeEnumChecks(gosrc, record, symbols.recordParam, func(e string) error {
+				return gosrc.Returns("-1", e)
+			})
+
+			// The updatedAt timestamps are assigned onto the record itself before its values are sent to the database.
+			writeCurrentTime(gosrc, record, scope.Get("receiver"), symbols.now, constants.ColumnUpdatedAtFlag)
+
+			for _, stamp := range record.timestampFields(constants.ColumnUpdatedAtFlag) {
+				gosrc.Println("%s.%s = %s", symbols.recordParam, stamp.name, symbols.now)
+			}
+
+			gosrc.Println("%s := bytes.NewBufferString(%s)", symbols.queryString, sqlExpression(command, placeholders...))
+			gosrc.Println("%s := []interface{}{%s}", symbols.values, strings.Join(values, ", "))
+
+			gosrc.Println(
+				"%s, %s := %s.PrepareContext(%s, %s.String())",
+				symbols.statementResult,
+				symbols.statementError,
+				scope.Get("receiver"),
+				ctx,
+				symbols.queryString,
+			)
+
+			gosrc.WithIf("%s != nil", func(url.Values) error {
+				return gosrc.Returns("-1", symbols.statementError)
+			}, symbols.statementError)
+
+			gosrc.Println("defer %s.Close()", symbols.statementResult)
+
+			logwriter.AddLog(symbols.queryString, symbols.values)
+
+			gosrc.Println("%s, %s := %s.ExecContext(%s, %s...)",
+				symbols.queryResult,
+				symbols.queryError,
+				symbols.statementResult,
+				ctx,
+				symbols.values,
+			)
+
+			gosrc.WithIf("%s != nil", func(url.Values) error {
+				return gosrc.Returns("-1", symbols.queryError)
+			}, symbols.queryError)
+
+			gosrc.Println("%s, %s := %s.RowsAffected()", symbols.rowCount, symbols.rowError, symbols.queryResult)
+
+			gosrc.WithIf("%s != nil", func(url.Values) error {
+				return gosrc.Returns("-1", symbols.rowError)
+			}, symbols.rowError)
+
+			if versioned {
+				gosrc.WithIf("%s == 0", func(url.Values) error {
+					return gosrc.Returns("0", record.staleError())
+				}, symbols.rowCount)
+
+				gosrc.Println("%s.%s++", symbols.recordParam, versionName)
+			}
+
+			return gosrc.Returns(symbols.rowCount, writing.Nil)
+		})
+
+		if e == nil {
+			record.registerImports("fmt", "bytes")
+		}
+
+		pw.CloseWithError(e)
+	}()
+
+	return pr
+}
+
+// staleErrorDeclaration writes the error returned by the update methods of versioned records when no rows matched.
+func staleErrorDeclaration(record marlowRecord) io.Reader {
+	pr, pw := io.Pipe()
+
+	go func() {
+		gosrc := writing.NewGoWriter(pw)
+		comment := "%s is returned by the update methods when no %s matches the expected version."
+		gosrc.Comment(comment, record.staleError(), record.name())
+		stale, name := record.staleError(), strings.ToLower(record.name())
+		gosrc.Println("var %s = fmt.Errorf(\"stale %s: %%w\", %s)\n", stale, name, constants.SharedStaleErrorName)
+		record.registerImports("fmt")
+		pw.Close()
+	}()
+
+	return pr
+}
+
+// versionedStruct returns true if the struct declares a version field used by the updaters of its store.
+func versionedStruct(structType *ast.StructType, config url.Values) bool {
+	if config.Get(constants.UpdateableConfigOption) == "false" {
+		return false
+	}
+
+	for _, f := range structType.Fields.List {
+		_, fieldConfig, ok := parseField(f)
+
+		if !ok || fieldConfig.Get(constants.ColumnConfigOption) == "-" {
+			continue
+		}
+
+		if _, version := fieldConfig[constants.ColumnVersionFlag]; version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sharedStaleError returns true if the error wrapped by the stale error of every versioned record in the package is
+// declared in the generated code of the file; alongside the records of the first file (by name) with versioned records.
+func sharedStaleError(file string, records []packageRecord) bool {
+	owner := ""
+
+	for _, r := range records {
+		if r.versioned && (owner == "" || r.file < owner) {
+			owner = r.file
+		}
+	}
+
+	return owner != "" && owner == filepath.Base(file)
+}
+
+// newStaleErrorGenerator returns a reader that will generate the error wrapped by the stale error of every versioned
+// record in the package, allowing callers to check for stale updates of any record with errors.Is.
+func newStaleErrorGenerator(imports chan<- string) io.Reader {
+	pr, pw := io.Pipe()
+
+	go func() {
+		gosrc := writing.NewGoWriter(pw)
+		comment := "%s is wrapped by the error returned when no rows match the version of any versioned record."
+		gosrc.Comment(comment, constants.SharedStaleErrorName)
+		gosrc.Println("var %s = errors.New(\"stale record\")\n", constants.SharedStaleErrorName)
+		imports <- "errors"
+		pw.Close()
+	}()
+
+	return pr
+}
+
 // newUpdateableGenerator is responsible for generating updating store methods.
 func newUpdateableGenerator(record marlowRecord) io.Reader {
-	readers := make([]io.Reader, 0, len(record.fields))
+	readers := []io.Reader{recordUpdater(record)}
 	prefix := record.config.Get(constants.UpdateFieldMethodPrefixConfigOption)
 
+	if _, _, versioned := record.versionField(); versioned {
+		readers = append([]io.Reader{staleErrorDeclaration(record)}, readers...)
+	}
+
 	for name, config := range record.fields {
+		_, version := config[constants.ColumnVersionFlag]
+
+		// Fields flagged with `updateable=false` do not receive any of the single column updater methods. The version
+		// column is only ever incremented by the other update methods.
+		if config.Get(constants.UpdateableConfigOption) == "false" || version {
+			continue
+		}
+
 		column := config.Get(constants.ColumnConfigOption)
 		method := fmt.Sprintf("%s%s%s", prefix, record.name(), name)
 		up := updater(record, config, method, "")
-		fieldType := getTypeInfo(config.Get("type"))
+		fieldType := fieldTypeInfo(config)
 
 		if _, bit := config[constants.ColumnBitmaskOption]; bit {
-			valid := (fieldType & (types.IsUnsigned | types.IsInteger)) == fieldType
+			valid := (fieldType&(types.IsUnsigned|types.IsInteger)) == fieldType && !nullableField(config)
 
 			if !valid {
 				e := fmt.Errorf("bitmask columns must be unsigned integers, %s has type \"%s\"", column, config.Get("type"))
@@ -192,9 +546,11 @@ func newUpdateableGenerator(record marlowRecord) io.Reader {
 				return pr
 			}
 
+			quoted := record.quote(column)
+
 			bitwise := []io.Reader{
-				updater(record, config, fmt.Sprintf("Add%s%s", record.name(), name), fmt.Sprintf("%s | %%s", column)),
-				updater(record, config, fmt.Sprintf("Drop%s%s", record.name(), name), fmt.Sprintf("%s & ~%%s", column)),
+				updater(record, config, fmt.Sprintf("Add%s%s", record.name(), name), fmt.Sprintf("%s | %%s", quoted)),
+				updater(record, config, fmt.Sprintf("Drop%s%s", record.name(), name), fmt.Sprintf("%s & ~%%s", quoted)),
 			}
 
 			readers = append(readers, bitwise...)
@@ -4,6 +4,7 @@ import "io"
 import "sync"
 import "bytes"
 import "testing"
+import "strings"
 import "net/url"
 import "github.com/franela/goblin"
 import "github.com/dadleyy/marlow/marlow/writing"
@@ -122,6 +123,39 @@ func Test_Updateable(t *testing.T) {
 				})
 			})
 
+			g.It("accepts every nullable database/sql type by reference", func() {
+				scaffold.fields["Nickname"] = url.Values{
+					"type": []string{"sql.NullString"},
+				}
+
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				g.Assert(strings.Contains(scaffold.buffer.String(), "_updates *sql.NullInt64")).Equal(true)
+				g.Assert(strings.Contains(scaffold.buffer.String(), "_updates *sql.NullString")).Equal(true)
+			})
+
+			g.Describe("with a nullable bitmask field type", func() {
+				g.BeforeEach(func() {
+					scaffold.fields["Flag"]["type"] = []string{"sql.NullInt64"}
+				})
+
+				g.It("raises an error", func() {
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e != nil).Equal(true)
+				})
+			})
+
+			g.Describe("with a pointer bitmask field type", func() {
+				g.BeforeEach(func() {
+					scaffold.fields["Flag"]["type"] = []string{"*uint8"}
+				})
+
+				g.It("raises an error", func() {
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e != nil).Equal(true)
+				})
+			})
+
 			g.Describe("with a postgres record dialect", func() {
 				g.BeforeEach(func() {
 					scaffold.record.Set(constants.DialectConfigOption, "postgres")
@@ -133,6 +167,135 @@ func Test_Updateable(t *testing.T) {
 				})
 			})
 
+			g.Describe("with a primary key field", func() {
+				g.BeforeEach(func() {
+					scaffold.fields["ID"].Set(constants.ColumnConfigOption, "id")
+					scaffold.fields["ID"].Set(constants.PrimaryKeyColumnConfigOption, "true")
+					scaffold.fields["Name"].Set(constants.ColumnConfigOption, "name")
+					scaffold.fields["UniversityID"].Set(constants.ColumnConfigOption, "university_id")
+					scaffold.fields["Flag"].Set(constants.ColumnConfigOption, "flag")
+				})
+
+				g.It("generates the whole record updater, targeting the primary key", func() {
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					update := "UPDATE authors SET flag = ?, name = ?, university_id = ? WHERE id = ?;"
+					g.Assert(strings.Contains(scaffold.buffer.String(), update)).Equal(true)
+				})
+
+				g.It("skips fields that are not updateable", func() {
+					scaffold.fields["Name"].Set(constants.UpdateableConfigOption, "false")
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					update := "UPDATE authors SET flag = ?, university_id = ? WHERE id = ?;"
+					g.Assert(strings.Contains(scaffold.buffer.String(), update)).Equal(true)
+					g.Assert(strings.Contains(scaffold.buffer.String(), "UpdateAuthorName(")).Equal(false)
+				})
+
+				g.Describe("with a version field", func() {
+					g.BeforeEach(func() {
+						scaffold.fields["Version"] = url.Values{
+							"type":                       []string{"int"},
+							constants.ColumnConfigOption: []string{"version"},
+							constants.ColumnVersionFlag:  []string{""},
+						}
+					})
+
+					g.It("declares the stale record error, wrapping the shared stale error of the package", func() {
+						_, e := io.Copy(scaffold.buffer, scaffold.g())
+						g.Assert(e).Equal(nil)
+						declaration := "var ErrStaleAuthor = fmt.Errorf(\"stale author: %w\", ErrStaleRecord)"
+						g.Assert(strings.Contains(scaffold.buffer.String(), declaration)).Equal(true)
+					})
+
+					g.It("checks & increments the version in the whole record updater", func() {
+						_, e := io.Copy(scaffold.buffer, scaffold.g())
+						g.Assert(e).Equal(nil)
+						s := scaffold.buffer.String()
+						update := "SET flag = ?, name = ?, university_id = ?, version = version + 1 WHERE id = ? AND version = ?;"
+						g.Assert(strings.Contains(s, update)).Equal(true)
+						g.Assert(strings.Contains(s, "_record.Version++")).Equal(true)
+					})
+
+					g.It("requires the expected version in the single field updaters", func() {
+						_, e := io.Copy(scaffold.buffer, scaffold.g())
+						g.Assert(e).Equal(nil)
+						s := scaffold.buffer.String()
+						g.Assert(strings.Contains(s, "UpdateAuthorName(_updates string,_version int,_blueprint")).Equal(true)
+						g.Assert(strings.Contains(s, "UPDATE authors SET name = %s, version = version + 1")).Equal(true)
+						g.Assert(strings.Contains(s, "_where := \"version = ?\"")).Equal(true)
+						g.Assert(strings.Contains(s, "return 0,ErrStaleAuthor")).Equal(true)
+					})
+
+					g.It("rejects single field updates without a limiting blueprint", func() {
+						_, e := io.Copy(scaffold.buffer, scaffold.g())
+						g.Assert(e).Equal(nil)
+						s := scaffold.buffer.String()
+						g.Assert(strings.Contains(s, "if _blueprint == nil || _blueprint.where(1) == \"\" {")).Equal(true)
+						g.Assert(strings.Contains(s, constants.InvalidVersionedUpdateBlueprint)).Equal(true)
+					})
+
+					g.It("does not generate a single field updater for the version", func() {
+						_, e := io.Copy(scaffold.buffer, scaffold.g())
+						g.Assert(e).Equal(nil)
+						g.Assert(strings.Contains(scaffold.buffer.String(), "UpdateAuthorVersion(")).Equal(false)
+					})
+
+					g.It("uses the numbered placeholder following the target value for postgres", func() {
+						scaffold.record.Set(constants.DialectConfigOption, "postgres")
+						_, e := io.Copy(scaffold.buffer, scaffold.g())
+						g.Assert(e).Equal(nil)
+						g.Assert(strings.Contains(scaffold.buffer.String(), "fmt.Sprintf(\"$%d\", _valueCount+1)")).Equal(true)
+					})
+				})
+
+				g.Describe("with timestamp fields", func() {
+					g.BeforeEach(func() {
+						scaffold.fields["CreatedAt"] = url.Values{
+							"type":                        []string{"time.Time"},
+							constants.ColumnConfigOption:  []string{"created_at"},
+							constants.ColumnCreatedAtFlag: []string{""},
+						}
+
+						scaffold.fields["UpdatedAt"] = url.Values{
+							"type":                        []string{"time.Time"},
+							constants.ColumnConfigOption:  []string{"updated_at"},
+							constants.ColumnUpdatedAtFlag: []string{""},
+						}
+					})
+
+					g.It("sets the updated_at column alongside single field & bitmask updates", func() {
+						_, e := io.Copy(scaffold.buffer, scaffold.g())
+						g.Assert(e).Equal(nil)
+						s := scaffold.buffer.String()
+						g.Assert(strings.Contains(s, "UPDATE authors SET name = %s, updated_at = %s")).Equal(true)
+						g.Assert(strings.Contains(s, "UPDATE authors SET flag = flag | %s, updated_at = %s")).Equal(true)
+						g.Assert(strings.Contains(s, "_values = append(_values, _updates, _now)")).Equal(true)
+					})
+
+					g.It("does not set the updated_at column twice when it is the column being updated", func() {
+						_, e := io.Copy(scaffold.buffer, scaffold.g())
+						g.Assert(e).Equal(nil)
+						g.Assert(strings.Contains(scaffold.buffer.String(), "SET updated_at = %s\"")).Equal(true)
+					})
+
+					g.It("assigns updated_at, leaving created_at untouched, in the whole record updater", func() {
+						_, e := io.Copy(scaffold.buffer, scaffold.g())
+						g.Assert(e).Equal(nil)
+						update := "UPDATE authors SET flag = ?, name = ?, university_id = ?, updated_at = ? WHERE id = ?;"
+						g.Assert(strings.Contains(scaffold.buffer.String(), update)).Equal(true)
+						g.Assert(strings.Contains(scaffold.buffer.String(), "_record.UpdatedAt = _now")).Equal(true)
+					})
+
+					g.It("uses the numbered placeholder following the target value for postgres", func() {
+						scaffold.record.Set(constants.DialectConfigOption, "postgres")
+						_, e := io.Copy(scaffold.buffer, scaffold.g())
+						g.Assert(e).Equal(nil)
+						g.Assert(strings.Contains(scaffold.buffer.String(), "fmt.Sprintf(\"$%d\", _valueCount+1)")).Equal(true)
+					})
+				})
+			})
+
 		})
 
 	})
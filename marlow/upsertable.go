@@ -0,0 +1,228 @@
+package marlow
+
+import "io"
+import "fmt"
+import "sort"
+import "strings"
+import "net/url"
+import "github.com/gedex/inflector"
+import "github.com/dadleyy/marlow/marlow/writing"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+type upsertableSymbolList struct {
+	createableSymbolList
+	conflictParam   string
+	conflictItem    string
+	conflictColumns string
+	conflictColumn  string
+	conflictKnown   string
+	knownColumns    string
+}
+
+// newUpsertableGenerator returns a reader that will generate a record store's upsert (insert-or-update) api.
+func newUpsertableGenerator(record marlowRecord) io.Reader {
+	pr, pw := io.Pipe()
+	methodName := fmt.Sprintf("Upsert%s", inflector.Pluralize(record.name()))
+
+	symbols := upsertableSymbolList{
+		createableSymbolList: createableSymbolList{
+			recordParam:              "_records",
+			queryBuffer:              "_query",
+			rowValueString:           "_placeholders",
+			statementPlaceholderList: "_placeholderList",
+			statementValueList:       "_valueList",
+			statement:                "_statement",
+			statementError:           "_e",
+			singleRecord:             "_record",
+			execResult:               "_result",
+			execError:                "_execError",
+			affectedResult:           "_affectedResult",
+			affectedError:            "_affectedError",
+			now:                      "_now",
+			recordIndex:              "_",
+		},
+		conflictParam:   "_conflicts",
+		conflictItem:    "_c",
+		conflictColumns: "_conflictColumns",
+		conflictColumn:  "_column",
+		conflictKnown:   "_ok",
+		knownColumns:    "_known",
+	}
+
+	if record.dialect().NumberedPlaceholders() {
+		symbols.recordIndex = "_recordIndex"
+	}
+
+	params := []writing.FuncParam{
+		{Symbol: symbols.conflictParam, Type: "[]string"},
+		{Symbol: symbols.recordParam, Type: fmt.Sprintf("...%s", record.name())},
+	}
+
+	returns := []string{
+		"int64",
+		"error",
+	}
+
+	// The conflict columns are provided at runtime; only columns known to the record are accepted.
+	known := make([]string, 0, len(record.fields))
+
+	for _, config := range record.fields {
+		column := config.Get(constants.ColumnConfigOption)
+		known = append(known, fmt.Sprintf("\"%s\": \"%s\"", column, record.quote(column)))
+	}
+
+	sort.Strings(known)
+
+	// Conflicting rows are updated using every inserted column that has not been flagged as `updateable=false`. The
+	// createdAt timestamp of an existing row is left untouched while its version is incremented, making the upsert
+	// visible to the optimistic locking of the updaters.
+	updates := make([]string, 0, len(record.fields))
+
+	for _, f := range record.fieldList(nil) {
+		config := record.fields[f.name]
+
+		if config.Get(constants.ColumnAutoIncrementFlag) != "" || config.Get(constants.UpdateableConfigOption) == "false" {
+			continue
+		}
+
+		_, createdAt := config[constants.ColumnCreatedAtFlag]
+		_, version := config[constants.ColumnVersionFlag]
+
+		if createdAt || version {
+			continue
+		}
+
+		updates = append(updates, record.quote(config.Get(constants.ColumnConfigOption)))
+	}
+
+	if len(updates) == 0 {
+		pw.CloseWithError(fmt.Errorf("upsertable records require at least one updateable column (%s)", record.name()))
+		return pr
+	}
+
+	assignments := make([]string, 0, 1)
+
+	if versionName, _, versioned := record.versionField(); versioned {
+		versionColumn := record.fields[versionName].Get(constants.ColumnConfigOption)
+		increment := fmt.Sprintf("%s = %s + 1", record.quote(versionColumn), record.columnReference(versionColumn))
+		assignments = append(assignments, increment)
+	}
+
+	go func() {
+		gosrc := writing.NewGoWriter(pw)
+
+		gosrc.Comment("[marlow] upsertable")
+
+		// The conflict columns are still validated for dialects that cannot use them, documented on the method instead.
+		if !record.dialect().UpsertConflictTarget() {
+			table, name := record.table(), record.name()
+			gosrc.Comment("Upserts of %s records update the rows conflicting with any unique key of the %s table.", name, table)
+			gosrc.Comment("The conflict columns must be columns of %s but do not limit the keys checked for conflicts.", name)
+		}
+
+		e := writeContextualMethod(gosrc, record, methodName, params, returns, func(ctx string, scope url.Values) error {
+			logwriter := logWriter{output: gosrc, receiver: scope.Get("receiver")}
+
+			gosrc.WithIf("len(%s) == 0", func(url.Values) error {
+				return gosrc.Returns("0", writing.Nil)
+			}, symbols.recordParam)
+
+			gosrc.WithIf("len(%s) == 0", func(url.Values) error {
+				return gosrc.Returns("-1", fmt.Sprintf("fmt.Errorf(\"%s\")", constants.InvalidUpsertConflictError))
+			}, symbols.conflictParam)
+
+			gosrc.Println("%s := map[string]string{%s}", symbols.knownColumns, strings.Join(known, ", "))
+			gosrc.Println("%s := make([]string, 0, len(%s))", symbols.conflictColumns, symbols.conflictParam)
+
+			gosrc.WithIter("_, %s := range %s", func(url.Values) error {
+				gosrc.Println(
+					"%s, %s := %s[%s]",
+					symbols.conflictColumn,
+					symbols.conflictKnown,
+					symbols.knownColumns,
+					symbols.conflictItem,
+				)
+
+				gosrc.WithIf("%s != true", func(url.Values) error {
+					message := fmt.Sprintf(
+						"fmt.Errorf(\"%s: %%s\", %s)",
+						constants.InvalidUpsertConflictColumnError,
+						symbols.conflictItem,
+					)
+					return gosrc.Returns("-1", message)
+				}, symbols.conflictKnown)
+
+				return gosrc.Println(
+					"%s = append(%s, %s)",
+					symbols.conflictColumns,
+					symbols.conflictColumns,
+					symbols.conflictColumn,
+				)
+			}, symbols.conflictItem, symbols.conflictParam)
+
+			// Like the updaters, upserts always send the current time of the store as the updatedAt timestamps.
+			writeCurrentTime(gosrc, record, scope.Get("receiver"), symbols.now, timestampFlags...)
+			columns := writeInsertRows(gosrc, record, symbols.createableSymbolList, constants.ColumnUpdatedAtFlag)
+
+			gosrc.Println("%s := new(bytes.Buffer)", symbols.queryBuffer)
+
+			insertStatement := fmt.Sprintf(
+				"INSERT INTO %s (%s) VALUES %%s",
+				record.quote(record.table()),
+				strings.Join(columns, ","),
+			)
+
+			gosrc.Println(
+				"fmt.Fprintf(%s, \"%s\", strings.Join(%s, \", \"))",
+				symbols.queryBuffer,
+				insertStatement,
+				symbols.statementPlaceholderList,
+			)
+
+			conflict := fmt.Sprintf("strings.Join(%s, \",\")", symbols.conflictColumns)
+			clause := record.dialect().Upsert(conflict, updates, assignments...)
+			gosrc.Println("fmt.Fprintf(%s, \"%%s;\", %s)\n", symbols.queryBuffer, clause)
+
+			logwriter.AddLog(symbols.queryBuffer, symbols.statementValueList)
+
+			gosrc.Println(
+				"%s, %s := %s.PrepareContext(%s, %s.String())",
+				symbols.statement,
+				symbols.statementError,
+				scope.Get("receiver"),
+				ctx,
+				symbols.queryBuffer,
+			)
+
+			gosrc.WithIf("%s != nil", func(url.Values) error {
+				return gosrc.Returns("-1", symbols.statementError)
+			}, symbols.statementError)
+
+			gosrc.Println("defer %s.Close()\n", symbols.statement)
+
+			gosrc.Println(
+				"%s, %s := %s.ExecContext(%s, %s...)",
+				symbols.execResult,
+				symbols.execError,
+				symbols.statement,
+				ctx,
+				symbols.statementValueList,
+			)
+
+			gosrc.WithIf("%s != nil", func(url.Values) error {
+				return gosrc.Returns("-1", symbols.execError)
+			}, symbols.execError)
+
+			gosrc.Println("%s, %s := %s.RowsAffected()", symbols.affectedResult, symbols.affectedError, symbols.execResult)
+			return gosrc.Returns(symbols.affectedResult, symbols.affectedError)
+		})
+
+		if e == nil {
+			record.registerImports("fmt", "bytes", "strings")
+		}
+
+		pw.CloseWithError(e)
+	}()
+
+	return pr
+}
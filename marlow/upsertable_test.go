@@ -0,0 +1,216 @@
+package marlow
+
+import "io"
+import "fmt"
+import "sync"
+import "bytes"
+import "testing"
+import "strings"
+import "net/url"
+import "go/token"
+import "go/parser"
+import "github.com/franela/goblin"
+import "github.com/dadleyy/marlow/marlow/writing"
+import "github.com/dadleyy/marlow/marlow/constants"
+
+type upsertableTestScaffold struct {
+	buffer *bytes.Buffer
+
+	imports chan string
+	methods chan writing.FuncDecl
+
+	record url.Values
+	fields map[string]url.Values
+
+	received map[string]bool
+	closed   bool
+	wg       *sync.WaitGroup
+}
+
+func (s *upsertableTestScaffold) close() {
+	if s == nil || s.closed {
+		return
+	}
+
+	s.closed = true
+	close(s.imports)
+	close(s.methods)
+	s.wg.Wait()
+}
+
+func (s *upsertableTestScaffold) g() io.Reader {
+	record := marlowRecord{
+		fields:        s.fields,
+		config:        s.record,
+		importChannel: s.imports,
+		storeChannel:  s.methods,
+	}
+
+	return newUpsertableGenerator(record)
+}
+
+func Test_Upsertable(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	var scaffold *upsertableTestScaffold
+
+	g.Describe("upsertable feature generator test suite", func() {
+
+		g.BeforeEach(func() {
+			scaffold = &upsertableTestScaffold{
+				buffer: new(bytes.Buffer),
+				wg:     &sync.WaitGroup{},
+
+				imports: make(chan string),
+				methods: make(chan writing.FuncDecl),
+
+				record:   make(url.Values),
+				fields:   make(map[string]url.Values),
+				received: make(map[string]bool),
+				closed:   false,
+			}
+
+			scaffold.wg.Add(2)
+
+			go func() {
+				for range scaffold.methods {
+				}
+				scaffold.wg.Done()
+			}()
+
+			go func() {
+				for i := range scaffold.imports {
+					scaffold.received[i] = true
+				}
+				scaffold.wg.Done()
+			}()
+		})
+
+		g.AfterEach(func() {
+			scaffold.close()
+		})
+
+		g.Describe("with a valid record config", func() {
+
+			g.BeforeEach(func() {
+				scaffold.record.Set(constants.RecordNameConfigOption, "Author")
+				scaffold.record.Set(constants.TableNameConfigOption, "authors")
+				scaffold.record.Set(constants.StoreNameConfigOption, "AuthorStore")
+
+				scaffold.fields["ID"] = url.Values{
+					"type":          []string{"int"},
+					"column":        []string{"id"},
+					"autoIncrement": []string{"true"},
+				}
+
+				scaffold.fields["Name"] = url.Values{
+					"type":   []string{"string"},
+					"column": []string{"name"},
+				}
+
+				scaffold.fields["Email"] = url.Values{
+					"type":   []string{"string"},
+					"column": []string{"email"},
+				}
+			})
+
+			g.It("generates valid golang", func() {
+				fmt.Fprintln(scaffold.buffer, "package marlowt")
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				_, e = parser.ParseFile(token.NewFileSet(), "", scaffold.buffer, parser.AllErrors)
+				g.Assert(e).Equal(nil)
+			})
+
+			g.It("uses the on conflict syntax, skipping auto increment columns", func() {
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				clause := "DO UPDATE SET email = excluded.email, name = excluded.name"
+				g.Assert(strings.Contains(scaffold.buffer.String(), clause)).Equal(true)
+			})
+
+			g.It("does not update columns flagged as not updateable", func() {
+				scaffold.fields["Email"].Set(constants.UpdateableConfigOption, "false")
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				g.Assert(strings.Contains(scaffold.buffer.String(), "DO UPDATE SET name = excluded.name\"")).Equal(true)
+			})
+
+			g.It("increments the version of conflicting rows", func() {
+				scaffold.fields["Version"] = url.Values{
+					"type":                       []string{"int"},
+					constants.ColumnConfigOption: []string{"version"},
+					constants.ColumnVersionFlag:  []string{""},
+				}
+
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				clause := "DO UPDATE SET email = excluded.email, name = excluded.name, version = authors.version + 1"
+				g.Assert(strings.Contains(scaffold.buffer.String(), clause)).Equal(true)
+			})
+
+			g.It("always sets the updatedAt timestamps to the store's clock, defaulting the createdAt timestamps", func() {
+				scaffold.fields["CreatedAt"] = url.Values{
+					"type":                        []string{"time.Time"},
+					constants.ColumnConfigOption:  []string{"created_at"},
+					constants.ColumnCreatedAtFlag: []string{""},
+				}
+
+				scaffold.fields["UpdatedAt"] = url.Values{
+					"type":                        []string{"time.Time"},
+					constants.ColumnConfigOption:  []string{"updated_at"},
+					constants.ColumnUpdatedAtFlag: []string{""},
+				}
+
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e).Equal(nil)
+				s := scaffold.buffer.String()
+				g.Assert(strings.Contains(s, "_now := a.clock()")).Equal(true)
+				g.Assert(strings.Contains(s, "if _record.CreatedAt.IsZero() {")).Equal(true)
+				g.Assert(strings.Contains(s, "_record.UpdatedAt.IsZero()")).Equal(false)
+				g.Assert(strings.Contains(s, "_record.UpdatedAt = _now")).Equal(true)
+			})
+
+			g.It("returns an error if no columns are updateable", func() {
+				scaffold.fields["Email"].Set(constants.UpdateableConfigOption, "false")
+				scaffold.fields["Name"].Set(constants.UpdateableConfigOption, "false")
+				_, e := io.Copy(scaffold.buffer, scaffold.g())
+				g.Assert(e == nil).Equal(false)
+			})
+
+			g.Describe("with a mysql record dialect", func() {
+				g.BeforeEach(func() {
+					scaffold.record.Set(constants.DialectConfigOption, "mysql")
+				})
+
+				g.It("uses the on duplicate key syntax", func() {
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					clause := "ON DUPLICATE KEY UPDATE `email` = VALUES(`email`), `name` = VALUES(`name`)"
+					g.Assert(strings.Contains(scaffold.buffer.String(), clause)).Equal(true)
+				})
+
+				g.It("documents that conflicts are detected using every unique key of the table", func() {
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					comment := "// Upserts of Author records update the rows conflicting with any unique key of the authors table."
+					g.Assert(strings.Contains(scaffold.buffer.String(), comment)).Equal(true)
+				})
+			})
+
+			g.Describe("with a postgres record dialect", func() {
+				g.BeforeEach(func() {
+					scaffold.record.Set(constants.DialectConfigOption, "postgres")
+				})
+
+				g.It("generates valid golang", func() {
+					fmt.Fprintln(scaffold.buffer, "package marlowt")
+					_, e := io.Copy(scaffold.buffer, scaffold.g())
+					g.Assert(e).Equal(nil)
+					_, e = parser.ParseFile(token.NewFileSet(), "", scaffold.buffer, parser.AllErrors)
+					g.Assert(e).Equal(nil)
+				})
+			})
+		})
+	})
+}
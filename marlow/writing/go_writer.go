@@ -25,6 +25,7 @@ type GoWriter interface {
 	WriteCall(...string) error
 	WithFunc(string, []FuncParam, []string, Block) error
 	WithMethod(string, string, []FuncParam, []string, Block) error
+	WithValueMethod(string, string, []FuncParam, []string, Block) error
 	WithIf(string, Block, ...interface{}) error
 	WithIter(string, Block, ...interface{}) error
 	WithStruct(string, Block) error
@@ -44,7 +44,7 @@ func (w *goWriter) Returns(msg ...string) error {
 
 func (w *goWriter) Comment(msg string, keys ...interface{}) {
 	comment := fmt.Sprintf(msg, keys...)
-	w.Println(fmt.Sprintf("// %s", comment))
+	w.Println("// %s", comment)
 }
 
 func (w *goWriter) WritePackage(packageName string) {
@@ -73,6 +73,14 @@ func (w *goWriter) WithIter(condition string, block Block, symbols ...interface{
 }
 
 func (w *goWriter) WithMethod(name string, typeName string, args []FuncParam, returns []string, block Block) error {
+	return w.withMethod(name, typeName, "*", args, returns, block)
+}
+
+func (w *goWriter) WithValueMethod(name, typeName string, args []FuncParam, returns []string, block Block) error {
+	return w.withMethod(name, typeName, "", args, returns, block)
+}
+
+func (w *goWriter) withMethod(name, typeName, pointer string, args []FuncParam, returns []string, block Block) error {
 	returnList := w.formatReturns(returns)
 	argList := w.formatArgList(args)
 
@@ -81,7 +89,7 @@ func (w *goWriter) WithMethod(name string, typeName string, args []FuncParam, re
 	}
 
 	receiver := strings.ToLower(typeName)[0:1]
-	funcDef := fmt.Sprintf("func (%s *%s) %s(%s) %s", receiver, typeName, name, argList, returnList)
+	funcDef := fmt.Sprintf("func (%s %s%s) %s(%s) %s", receiver, pointer, typeName, name, argList, returnList)
 	c := make(url.Values)
 	c.Set("receiver", receiver)
 	return w.withBlock(funcDef, block, c)
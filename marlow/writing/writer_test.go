@@ -201,6 +201,20 @@ func Test_goWriter(t *testing.T) {
 
 		})
 
+		g.Describe("WithValueMethod", func() {
+			g.It("returns an invalid receiver error if type name is too short", func() {
+				e := w.WithValueMethod("myFunc", "", nil, nil, nil)
+				g.Assert(e.Error()).Equal("invalid-receiver")
+			})
+
+			g.It("uses a value receiver for the method", func() {
+				e := w.WithValueMethod("myFunc", "myType", nil, []string{"bool"}, nil)
+				g.Assert(e).Equal(nil)
+				g.Assert(strings.Contains(b.String(), "func (m myType) myFunc() bool")).Equal(true)
+				g.Assert(b.ParseError()).Equal(nil)
+			})
+		})
+
 		g.Describe("WithFunc", func() {
 
 			g.It("returns the error that was returned from the inner func", func() {
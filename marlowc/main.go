@@ -5,10 +5,13 @@ import "io"
 import "fmt"
 import "flag"
 import "path"
+import "sort"
 import "sync"
 import "bytes"
 import "strings"
 import "go/build"
+import "io/ioutil"
+import "path/filepath"
 
 import "github.com/vbauerster/mpb"
 import "github.com/dustin/go-humanize"
@@ -16,6 +19,9 @@ import "github.com/dustin/go-humanize"
 import "github.com/dadleyy/marlow/marlow"
 import "github.com/dadleyy/marlow/marlow/constants"
 
+// recursiveInputSuffix marks inputs (e.g. `./...`) that compile every package found beneath a directory.
+const recursiveInputSuffix = "..."
+
 func main() {
 	cwd, e := os.Getwd()
 
@@ -32,7 +38,7 @@ func main() {
 	flag.Usage = usage
 	flag.Parse()
 
-	sourceFiles, e := loadFileNames(options.input)
+	targets, e := loadTargets(options.input)
 
 	if e != nil {
 		exit("unable to load package from input", e)
@@ -47,10 +53,18 @@ func main() {
 
 	fmt.Fprintf(progressOut, "starting progress")
 
-	total := len(sourceFiles)
+	total, loadFailures := 0, 0
+
+	for _, target := range targets {
+		total += len(target.files)
+
+		if target.e != nil {
+			loadFailures++
+		}
+	}
 
 	// If no files were found, exit.
-	if total == 0 {
+	if total == 0 && loadFailures == 0 {
 		exit("no source files found", nil)
 	}
 
@@ -61,67 +75,71 @@ func main() {
 	)
 	bar := progress.AddBar(int64(total))
 
-	// Keep a list of files that have been created to print out at the end.
-	results := make(map[string]int64)
-
-	for _, name := range sourceFiles {
-		// Skip files that have already bee compiled.
-		if strings.HasSuffix(path.Base(name), options.ext) {
-			bar.IncrBy(1)
-			continue
-		}
-
-		// Attempt to build the writer that we will copy the generated source into.
-		writer, e := options.writerFor(name)
+	// Compile every package concurrently, keeping the result of each in the order the packages were found.
+	results := make([]compileResult, len(targets))
+	wg := &sync.WaitGroup{}
 
-		if e != nil {
-			exit("unable to create writer for file", e)
-		}
+	for i, target := range targets {
+		wg.Add(1)
 
-		// Create our marlow compiler for the given file.
-		reader, e := marlow.NewReaderFromFile(name)
+		go func(index int, target compileTarget) {
+			results[index] = options.compile(target, bar)
+			wg.Done()
+		}(i, target)
+	}
 
-		if e != nil {
-			exit("unable to open output for file", e)
-		}
+	wg.Wait()
+	progress.Wait()
 
-		size, e := io.Copy(writer, reader)
+	failures := 0
 
-		if e != nil {
-			exit(fmt.Sprintf("unable to compile file %s", name), e)
+	// As the final step, loop over all packages printing out their errors or the name and size of their files.
+	for _, result := range results {
+		if result.e != nil {
+			failures++
+			fmt.Fprintf(os.Stderr, "Error: unable to compile package %s: %s\n", result.target.dir, result.e.Error())
+			continue
 		}
 
-		// If no data was copied we had an no-op gen source, remove the file and continue.
-		if size == 0 {
-			os.Remove(options.generatedName(name))
-			bar.IncrBy(1)
+		// If no files were the target of compilation, or the silent flag was used, do nothing.
+		if len(result.generated) == 0 || options.silent == true {
 			continue
 		}
 
-		fmt.Fprintf(os.Stdout, "completed compilation of %s\n", name)
+		fmt.Fprintf(os.Stdout, "success! files generated for %s:\n", result.target.dir)
 
-		results[options.generatedName(name)] = size
+		names := make([]string, 0, len(result.generated))
 
-		// Close the destination file/buffer.
-		writer.Close()
+		for name := range result.generated {
+			names = append(names, name)
+		}
 
-		// Let our progress bar know we're done.
-		bar.IncrBy(1)
-	}
+		sort.Strings(names)
 
-	progress.Wait()
+		for _, name := range names {
+			fmt.Fprintf(os.Stdout, " - %s (%s)\n", name, humanize.Bytes(uint64(result.generated[name])))
+		}
+	}
 
-	// If no files were the target of compilation, or the silent flag was used, do nothing.
-	if len(results) == 0 || options.silent == true {
-		return
+	if failures > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d of %d packages failed to compile\n", failures, len(results))
+		os.Exit(1)
 	}
+}
 
-	// As the final step, loop over all files printing out their name and size.
-	fmt.Fprintln(os.Stdout, "success! files generated:")
+// compileTarget records are the directories (packages) of golang source files compiled by marlowc, or the error that
+// prevented the source files of the package from being loaded.
+type compileTarget struct {
+	dir   string
+	files []string
+	e     error
+}
 
-	for name, size := range results {
-		fmt.Fprintf(os.Stdout, " - %s (%s)\n", name, humanize.Bytes(uint64(size)))
-	}
+// compileResult records hold the generated file sizes of a compiled package, or the error that stopped its compilation.
+type compileResult struct {
+	target    compileTarget
+	generated map[string]int64
+	e         error
 }
 
 type cliOptions struct {
@@ -137,6 +155,90 @@ func (o *cliOptions) generatedName(input string) string {
 	return path.Join(dir, name)
 }
 
+// compile generates the code for each file of the target, stopping at the first file that fails to compile.
+func (o *cliOptions) compile(target compileTarget, bar *mpb.Bar) compileResult {
+	result := compileResult{target: target, generated: make(map[string]int64), e: target.e}
+
+	if result.e != nil {
+		return result
+	}
+
+	for i, name := range target.files {
+		size, e := o.compileFile(name)
+
+		if e != nil {
+			result.e = e
+			bar.IncrBy(len(target.files) - i)
+			return result
+		}
+
+		// If no data was copied we had an no-op gen source.
+		if size > 0 {
+			result.generated[o.generatedName(name)] = size
+		}
+
+		// Let our progress bar know we're done.
+		bar.IncrBy(1)
+	}
+
+	return result
+}
+
+// compileFile generates the code for a single source file, returning the amount of generated bytes.
+func (o *cliOptions) compileFile(name string) (int64, error) {
+	// Skip files that have already bee compiled.
+	if strings.HasSuffix(path.Base(name), o.ext) {
+		return 0, nil
+	}
+
+	// Create our marlow compiler for the given file.
+	reader, e := marlow.NewReaderFromFile(name)
+
+	if e != nil {
+		return 0, fmt.Errorf("unable to open output for file: %s", e.Error())
+	}
+
+	// Attempt to build the writer that we will copy the generated source into.
+	writer, e := o.writerFor(name)
+
+	if e != nil {
+		return 0, fmt.Errorf("unable to create writer for file: %s", e.Error())
+	}
+
+	size, e := io.Copy(writer, reader)
+
+	// Remove the partially generated file; it would prevent the package from being loaded by later runs.
+	if e != nil {
+		o.discard(writer, name)
+		return 0, fmt.Errorf("unable to compile file %s: %s", name, e.Error())
+	}
+
+	// If no data was copied we had an no-op gen source, remove the file.
+	if size == 0 {
+		o.discard(writer, name)
+		return 0, nil
+	}
+
+	// Like the progress output, the completion of each file is only reported when not silent or printing to stdout.
+	if o.stdout == false && !o.silent {
+		fmt.Fprintf(os.Stdout, "completed compilation of %s\n", name)
+	}
+
+	// Close the destination file/buffer.
+	return size, writer.Close()
+}
+
+// discard closes the writer of a file whose generated code is not kept before removing the generated file. Buffers
+// used in place of files when printing to stdout are dropped without printing their contents.
+func (o *cliOptions) discard(writer io.WriteCloser, input string) {
+	if o.stdout == true {
+		return
+	}
+
+	writer.Close()
+	os.Remove(o.generatedName(input))
+}
+
 func (o *cliOptions) writerFor(input string) (io.WriteCloser, error) {
 	// If we're printing to stdout, just return a bytes.Buffer wrapped w/ a Close.
 	if o.stdout == true {
@@ -165,9 +267,9 @@ func usage() {
 
 func exit(msg string, e error) {
 	if e != nil {
-		fmt.Fprintf(os.Stderr, fmt.Sprintf("Error: %s: %s\n", msg, e.Error()))
+		fmt.Fprintf(os.Stderr, "Error: %s: %s\n", msg, e.Error())
 	} else {
-		fmt.Fprintf(os.Stderr, fmt.Sprintf("Error: %s\n", msg))
+		fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
 	}
 
 	flag.Usage()
@@ -175,6 +277,82 @@ func exit(msg string, e error) {
 	os.Exit(2)
 }
 
+// loadTargets returns the packages to compile for the input. Inputs ending with `...` (e.g. `./...`) walk the directory
+// tree beneath them, returning every package with source files containing marlow tags; vendor & testdata directories
+// are skipped along with those whose names begin with `.` or `_`, matching the go tool. Packages that cannot be loaded
+// are returned with their error, leaving the other packages to be compiled.
+func loadTargets(input string) ([]compileTarget, error) {
+	if !strings.HasSuffix(input, recursiveInputSuffix) {
+		files, e := loadFileNames(input)
+
+		if e != nil {
+			return nil, e
+		}
+
+		return []compileTarget{{dir: input, files: files}}, nil
+	}
+
+	root := path.Clean(strings.TrimSuffix(input, recursiveInputSuffix))
+	targets := make([]compileTarget, 0)
+
+	e := filepath.Walk(root, func(dir string, info os.FileInfo, e error) error {
+		if e != nil || !info.IsDir() {
+			return e
+		}
+
+		if dir != root && skipDirectory(info.Name()) {
+			return filepath.SkipDir
+		}
+
+		files, e := loadFileNames(dir)
+
+		if _, empty := e.(*build.NoGoError); empty {
+			return nil
+		}
+
+		if e != nil {
+			targets = append(targets, compileTarget{dir: dir, e: e})
+			return nil
+		}
+
+		tagged, e := containsTags(files)
+
+		if e != nil {
+			targets = append(targets, compileTarget{dir: dir, e: e})
+			return nil
+		}
+
+		if tagged {
+			targets = append(targets, compileTarget{dir: dir, files: files})
+		}
+
+		return nil
+	})
+
+	return targets, e
+}
+
+func skipDirectory(name string) bool {
+	return name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// containsTags returns true if any of the files contains a marlow field tag.
+func containsTags(files []string) (bool, error) {
+	for _, name := range files {
+		source, e := ioutil.ReadFile(name)
+
+		if e != nil {
+			return false, e
+		}
+
+		if bytes.Contains(source, []byte("marlow:\"")) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // loadFileNames uses the go/build package to get a list of all valid golang files.
 func loadFileNames(input string) ([]string, error) {
 	stat, e := os.Stat(input)
@@ -0,0 +1,127 @@
+package main
+
+import "os"
+import "testing"
+import "io/ioutil"
+import "path/filepath"
+import "github.com/franela/goblin"
+
+type loadTargetsTestScaffold struct {
+	root string
+}
+
+// write creates the source file (and its directory) beneath the scaffold root.
+func (s *loadTargetsTestScaffold) write(name, source string) {
+	full := filepath.Join(s.root, name)
+
+	if e := os.MkdirAll(filepath.Dir(full), 0755); e != nil {
+		panic(e)
+	}
+
+	if e := ioutil.WriteFile(full, []byte(source), 0644); e != nil {
+		panic(e)
+	}
+}
+
+func (s *loadTargetsTestScaffold) targets() map[string]compileTarget {
+	targets, e := loadTargets(filepath.Join(s.root, recursiveInputSuffix))
+
+	if e != nil {
+		panic(e)
+	}
+
+	result := make(map[string]compileTarget, len(targets))
+
+	for _, target := range targets {
+		rel, _ := filepath.Rel(s.root, target.dir)
+		result[filepath.ToSlash(rel)] = target
+	}
+
+	return result
+}
+
+func Test_LoadTargets(t *testing.T) {
+	g := goblin.Goblin(t)
+
+	var scaffold *loadTargetsTestScaffold
+
+	g.Describe("loadTargets test suite", func() {
+
+		g.BeforeEach(func() {
+			root, e := ioutil.TempDir("", "marlowc")
+
+			if e != nil {
+				panic(e)
+			}
+
+			scaffold = &loadTargetsTestScaffold{root: root}
+			scaffold.write("models/book.go", "package models\n\ntype Book struct {\n\tID int `marlow:\"column=id\"`\n}\n")
+			scaffold.write("models/author.go", "package models\n\ntype Author struct {\n\tName string\n}\n")
+			scaffold.write("cli/main.go", "package main\n\nfunc main() {\n}\n")
+		})
+
+		g.AfterEach(func() {
+			os.RemoveAll(scaffold.root)
+		})
+
+		g.It("returns the input as the only target when not recursive", func() {
+			targets, e := loadTargets(filepath.Join(scaffold.root, "models"))
+			g.Assert(e).Equal(nil)
+			g.Assert(len(targets)).Equal(1)
+			g.Assert(len(targets[0].files)).Equal(2)
+		})
+
+		g.It("returns every package beneath the input containing marlow tags", func() {
+			targets := scaffold.targets()
+			g.Assert(len(targets)).Equal(1)
+			g.Assert(len(targets["models"].files)).Equal(2)
+			g.Assert(targets["models"].e).Equal(nil)
+		})
+
+		g.It("finds tagged packages nested in directories without go files", func() {
+			scaffold.write("nested/deep/genre.go", "package deep\n\ntype Genre struct {\n\tID int `marlow:\"column=id\"`\n}\n")
+			targets := scaffold.targets()
+			g.Assert(len(targets)).Equal(2)
+			g.Assert(len(targets["nested/deep"].files)).Equal(1)
+		})
+
+		g.It("skips vendor, testdata & hidden directories", func() {
+			tagged := "package skipped\n\ntype Skipped struct {\n\tID int `marlow:\"column=id\"`\n}\n"
+			scaffold.write("vendor/skipped/skipped.go", tagged)
+			scaffold.write("testdata/skipped.go", tagged)
+			scaffold.write(".hidden/skipped.go", tagged)
+			scaffold.write("_ignored/skipped.go", tagged)
+			g.Assert(len(scaffold.targets())).Equal(1)
+		})
+
+		g.It("records the error of packages that cannot be loaded & keeps walking", func() {
+			scaffold.write("broken/a.go", "package a\n")
+			scaffold.write("broken/b.go", "package b\n")
+			scaffold.write("zoo/animal.go", "package zoo\n\ntype Animal struct {\n\tID int `marlow:\"column=id\"`\n}\n")
+			targets := scaffold.targets()
+			g.Assert(len(targets)).Equal(3)
+			g.Assert(targets["broken"].e == nil).Equal(false)
+			g.Assert(len(targets["broken"].files)).Equal(0)
+			g.Assert(targets["zoo"].e).Equal(nil)
+			g.Assert(len(targets["zoo"].files)).Equal(1)
+		})
+
+		g.It("reports the load error as the compilation result of the package", func() {
+			scaffold.write("broken/a.go", "package a\n")
+			scaffold.write("broken/b.go", "package b\n")
+			options := cliOptions{ext: ".marlow.go"}
+			result := options.compile(scaffold.targets()["broken"], nil)
+			g.Assert(result.e == nil).Equal(false)
+			g.Assert(len(result.generated)).Equal(0)
+		})
+
+		g.It("removes the generated file of sources without any records", func() {
+			options := cliOptions{ext: ".marlow.go"}
+			size, e := options.compileFile(filepath.Join(scaffold.root, "cli/main.go"))
+			g.Assert(e).Equal(nil)
+			g.Assert(size).Equal(int64(0))
+			_, e = os.Stat(filepath.Join(scaffold.root, "cli/main.marlow.go"))
+			g.Assert(os.IsNotExist(e)).Equal(true)
+		})
+	})
+}